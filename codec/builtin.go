@@ -0,0 +1,371 @@
+package codec
+
+import (
+	"fmt"
+	"iter"
+	"math"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+	"github.com/PlayerR9/GoSD/tree"
+	"github.com/PlayerR9/GoSD/types"
+)
+
+// Type IDs of the built-in types.
+const (
+	// IntID is the type ID of *types.Int.
+	IntID uint64 = iota + 1
+
+	// BoolID is the type ID of *types.Bool.
+	BoolID
+
+	// SliceID is the type ID of *slices.Slice[pkg.Type].
+	SliceID
+
+	// SetID is the type ID of *types.Set[pkg.Type].
+	SetID
+
+	// IndexID is the type ID of *slices.Index[pkg.Type].
+	IndexID
+
+	// IntSliceID is the type ID of *slices.Slice[*types.Int].
+	IntSliceID
+
+	// BoolSliceID is the type ID of *slices.Slice[*types.Bool].
+	BoolSliceID
+
+	// IntSetID is the type ID of *types.Set[*types.Int].
+	IntSetID
+
+	// BoolSetID is the type ID of *types.Set[*types.Bool].
+	BoolSetID
+
+	// StringWrapID is the type ID of *types.Wrap[string].
+	StringWrapID
+
+	// IntWrapID is the type ID of *types.Wrap[int].
+	IntWrapID
+
+	// FloatWrapID is the type ID of *types.Wrap[float64].
+	FloatWrapID
+
+	// BoolWrapID is the type ID of *types.Wrap[bool].
+	BoolWrapID
+
+	// FirstUserID is the first type ID that is not reserved for built-in types.
+	FirstUserID uint64 = 64
+)
+
+// register_builtins registers the built-in types.
+func register_builtins() {
+	Register(IntID, encode_int, decode_int)
+	Register(BoolID, encode_bool, decode_bool)
+
+	RegisterSlice[pkg.Type](SliceID)
+	RegisterSet[pkg.Type](SetID)
+	RegisterIndex[pkg.Type](IndexID)
+
+	RegisterSlice[*types.Int](IntSliceID)
+	RegisterSlice[*types.Bool](BoolSliceID)
+	RegisterSet[*types.Int](IntSetID)
+	RegisterSet[*types.Bool](BoolSetID)
+
+	RegisterWrap(StringWrapID, func(e *Encoder, v string) error {
+		e.WriteString(v)
+		return nil
+	}, (*Decoder).ReadString)
+
+	RegisterWrap(IntWrapID, func(e *Encoder, v int) error {
+		e.WriteVarint(int64(v))
+		return nil
+	}, read_int)
+
+	RegisterWrap(FloatWrapID, func(e *Encoder, v float64) error {
+		e.WriteUvarint(math.Float64bits(v))
+		return nil
+	}, func(d *Decoder) (float64, error) {
+		bits, err := d.ReadUvarint()
+		return math.Float64frombits(bits), err
+	})
+
+	RegisterWrap(BoolWrapID, func(e *Encoder, v bool) error {
+		e.WriteBool(v)
+		return nil
+	}, (*Decoder).ReadBool)
+}
+
+// read_int reads a varint and checks that it fits in an int.
+func read_int(d *Decoder) (int, error) {
+	x, err := d.ReadVarint()
+	if err != nil {
+		return 0, err
+	}
+
+	if int64(int(x)) != x {
+		return 0, pkg.NewIllegalArgument(fmt.Errorf("value %d overflows int", x))
+	}
+
+	return int(x), nil
+}
+
+func encode_int(e *Encoder, v *types.Int) error {
+	e.WriteVarint(int64(v.Value()))
+	return nil
+}
+
+func decode_int(d *Decoder) (*types.Int, error) {
+	x, err := read_int(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewInt().WithValue(x), nil
+}
+
+func encode_bool(e *Encoder, v *types.Bool) error {
+	e.WriteBool(v.Value())
+	return nil
+}
+
+func decode_bool(d *Decoder) (*types.Bool, error) {
+	b, err := d.ReadBool()
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewBool().WithValue(b), nil
+}
+
+// encode_seq writes a length prefix followed by every element.
+func encode_seq[T pkg.Type](e *Encoder, size int, seq iter.Seq[T]) error {
+	e.WriteUvarint(uint64(size))
+
+	for elem := range seq {
+		err := e.Encode(elem)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decode_seq reads a length prefix followed by every element.
+func decode_seq[T pkg.Type](d *Decoder) ([]T, error) {
+	n, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+
+	// Grow on demand so that a forged length cannot force a large allocation.
+	elems := make([]T, 0, min(n, 64))
+
+	for i := 0; i < n; i++ {
+		elem, err := DecodeAs[T](d)
+		if err != nil {
+			return nil, err
+		}
+
+		elems = append(elems, elem)
+	}
+
+	return elems, nil
+}
+
+// RegisterSlice registers *slices.Slice[T] under the given ID. Elements are
+// encoded with their own type ID, so T must be registered (or be an interface
+// whose dynamic types are registered) by the time values are encoded.
+//
+// Parameters:
+//   - id: The type ID.
+//
+// Panics under the same conditions as Register.
+func RegisterSlice[T pkg.Type](id uint64) {
+	enc := func(e *Encoder, v *slices.Slice[T]) error {
+		return encode_seq(e, v.Size(), v.Each())
+	}
+
+	dec := func(d *Decoder) (*slices.Slice[T], error) {
+		elems, err := decode_seq[T](d)
+		if err != nil {
+			return nil, err
+		}
+
+		return slices.NewSlice[T]().WithValue(elems), nil
+	}
+
+	Register(id, enc, dec)
+}
+
+// RegisterSet registers *types.Set[T] under the given ID. See RegisterSlice.
+//
+// Parameters:
+//   - id: The type ID.
+//
+// Panics under the same conditions as Register.
+func RegisterSet[T pkg.Type](id uint64) {
+	enc := func(e *Encoder, v *types.Set[T]) error {
+		return encode_seq(e, v.Size(), v.Each())
+	}
+
+	dec := func(d *Decoder) (*types.Set[T], error) {
+		elems, err := decode_seq[T](d)
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewSet[T]().WithValue(elems), nil
+	}
+
+	Register(id, enc, dec)
+}
+
+// RegisterIndex registers *slices.Index[T] under the given ID. The slice the
+// index refers to is encoded along with it.
+//
+// Parameters:
+//   - id: The type ID.
+//
+// Panics under the same conditions as Register.
+func RegisterIndex[T pkg.Type](id uint64) {
+	enc := func(e *Encoder, v *slices.Index[T]) error {
+		ref := v.Ref()
+		if ref == nil {
+			return pkg.NewInvalidState("v.ref", pkg.NewNilValue())
+		}
+
+		err := e.Encode(ref)
+		if err != nil {
+			return err
+		}
+
+		e.WriteVarint(int64(v.Value()))
+		e.WriteVarint(int64(pkg.OrElse(v.HasMax(), v.Max(), -1)))
+
+		return nil
+	}
+
+	dec := func(d *Decoder) (*slices.Index[T], error) {
+		ref, err := DecodeAs[*slices.Slice[T]](d)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := read_int(d)
+		if err != nil {
+			return nil, err
+		}
+
+		max, err := read_int(d)
+		if err != nil {
+			return nil, err
+		}
+
+		if max < -1 || max > ref.Size() {
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("index max %d is out of range", max))
+		}
+
+		idx := slices.NewIndex(ref)
+		if max != -1 {
+			idx = idx.WithMax(max)
+		}
+
+		if value != 0 {
+			if value < 0 || value >= idx.Max() {
+				return nil, pkg.NewIllegalArgument(fmt.Errorf("index value %d is out of range", value))
+			}
+
+			idx = idx.WithValue(value)
+		}
+
+		return idx, nil
+	}
+
+	Register(id, enc, dec)
+}
+
+// RegisterEnum registers *types.Enum[T] under the given ID.
+//
+// Parameters:
+//   - id: The type ID.
+//
+// Panics under the same conditions as Register.
+func RegisterEnum[T types.Enumer](id uint64) {
+	enc := func(e *Encoder, v *types.Enum[T]) error {
+		e.WriteVarint(int64(v.Value()))
+		return nil
+	}
+
+	dec := func(d *Decoder) (*types.Enum[T], error) {
+		x, err := read_int(d)
+		if err != nil {
+			return nil, err
+		}
+
+		if !types.IsEnumValue(T(x)) {
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("enum value %d is not registered", x))
+		}
+
+		return types.NewEnum(T(x)), nil
+	}
+
+	Register(id, enc, dec)
+}
+
+// RegisterWrap registers *types.Wrap[T] under the given ID.
+//
+// Parameters:
+//   - id: The type ID.
+//   - enc: The function that writes the wrapped value.
+//   - dec: The function that reads the wrapped value.
+//
+// Panics under the same conditions as Register.
+func RegisterWrap[T comparable](id uint64, enc func(e *Encoder, v T) error, dec func(d *Decoder) (T, error)) {
+	pkg.ThrowIf(enc == nil, pkg.NewInvalidCall("enc", pkg.NewNilValue()))
+	pkg.ThrowIf(dec == nil, pkg.NewInvalidCall("dec", pkg.NewNilValue()))
+
+	wrap_enc := func(e *Encoder, v *types.Wrap[T]) error {
+		return enc(e, v.Value())
+	}
+
+	wrap_dec := func(d *Decoder) (*types.Wrap[T], error) {
+		v, err := dec(d)
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewWrap(v), nil
+	}
+
+	Register(id, wrap_enc, wrap_dec)
+}
+
+// RegisterTree registers *tree.Tree[N] under the given ID. The tree is encoded
+// as its root node, so N must be registered and its encoder is responsible for
+// the children.
+//
+// Parameters:
+//   - id: The type ID.
+//
+// Panics under the same conditions as Register.
+func RegisterTree[N interface {
+	Child() iter.Seq[N]
+	BackwardChild() iter.Seq[N]
+
+	tree.TreeNoder
+}](id uint64) {
+	enc := func(e *Encoder, v *tree.Tree[N]) error {
+		return e.Encode(v.Root())
+	}
+
+	dec := func(d *Decoder) (*tree.Tree[N], error) {
+		root, err := DecodeAs[N](d)
+		if err != nil {
+			return nil, err
+		}
+
+		return tree.NewTree(root), nil
+	}
+
+	Register(id, enc, dec)
+}
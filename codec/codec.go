@@ -0,0 +1,343 @@
+package codec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+const (
+	// MaxDepth is the maximum nesting depth of an encoded value.
+	MaxDepth int = 512
+
+	// MaxLength is the maximum number of elements or bytes that a single
+	// length prefix may announce.
+	MaxLength uint64 = 1 << 24
+)
+
+// Encoder writes the binary form of SD values.
+type Encoder struct {
+	// buf is the buffer of the encoded bytes.
+	buf []byte
+
+	// depth is the current nesting depth.
+	depth int
+}
+
+// WriteUvarint writes an unsigned varint.
+//
+// Parameters:
+//   - x: The value to write.
+func (e *Encoder) WriteUvarint(x uint64) {
+	e.buf = binary.AppendUvarint(e.buf, x)
+}
+
+// WriteVarint writes a signed (zig-zag) varint.
+//
+// Parameters:
+//   - x: The value to write.
+func (e *Encoder) WriteVarint(x int64) {
+	e.buf = binary.AppendVarint(e.buf, x)
+}
+
+// WriteBool writes a bool as a single byte.
+//
+// Parameters:
+//   - b: The value to write.
+func (e *Encoder) WriteBool(b bool) {
+	e.buf = append(e.buf, pkg.OrElse[byte](b, 1, 0))
+}
+
+// WriteBytes writes a length-prefixed byte slice.
+//
+// Parameters:
+//   - data: The bytes to write.
+func (e *Encoder) WriteBytes(data []byte) {
+	e.WriteUvarint(uint64(len(data)))
+	e.buf = append(e.buf, data...)
+}
+
+// WriteString writes a length-prefixed string.
+//
+// Parameters:
+//   - s: The string to write.
+func (e *Encoder) WriteString(s string) {
+	e.WriteUvarint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// Encode writes the type ID of the value followed by its payload. This is
+// the method that encoders of containers call for their elements.
+//
+// Parameters:
+//   - v: The value to encode.
+//
+// Returns:
+//   - error: An error of type *pkg.Err if the value cannot be encoded.
+func (e *Encoder) Encode(v pkg.Type) error {
+	if is_nil(v) {
+		return pkg.NewInvalidCall("v", pkg.NewNilValue())
+	}
+
+	if e.depth >= MaxDepth {
+		return pkg.NewIllegalArgument(fmt.Errorf("value is nested deeper than %d levels", MaxDepth))
+	}
+
+	entry, ok := lookup_value(v)
+	if !ok {
+		return pkg.NewIllegalArgument(fmt.Errorf("type %T is not registered", v))
+	}
+
+	e.WriteUvarint(entry.id)
+
+	e.depth++
+	defer func() { e.depth-- }()
+
+	return entry.encode(e, v)
+}
+
+// Decoder reads the binary form of SD values.
+type Decoder struct {
+	// r is the underlying reader.
+	r io.ByteReader
+
+	// depth is the current nesting depth.
+	depth int
+}
+
+// ReadUvarint reads an unsigned varint.
+//
+// Returns:
+//   - uint64: The value read.
+//   - error: An error of type *pkg.Err if the input is malformed.
+func (d *Decoder) ReadUvarint() (uint64, error) {
+	x, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return 0, malformed(err)
+	}
+
+	return x, nil
+}
+
+// ReadVarint reads a signed (zig-zag) varint.
+//
+// Returns:
+//   - int64: The value read.
+//   - error: An error of type *pkg.Err if the input is malformed.
+func (d *Decoder) ReadVarint() (int64, error) {
+	x, err := binary.ReadVarint(d.r)
+	if err != nil {
+		return 0, malformed(err)
+	}
+
+	return x, nil
+}
+
+// ReadBool reads a bool written by Encoder.WriteBool.
+//
+// Returns:
+//   - bool: The value read.
+//   - error: An error of type *pkg.Err if the input is malformed.
+func (d *Decoder) ReadBool() (bool, error) {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return false, malformed(err)
+	}
+
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, pkg.NewIllegalArgument(fmt.Errorf("invalid bool byte: %#x", b))
+	}
+}
+
+// ReadLength reads a length prefix and checks it against MaxLength.
+//
+// Returns:
+//   - int: The length read.
+//   - error: An error of type *pkg.Err if the input is malformed.
+func (d *Decoder) ReadLength() (int, error) {
+	n, err := d.ReadUvarint()
+	if err != nil {
+		return 0, err
+	}
+
+	if n > MaxLength {
+		return 0, pkg.NewIllegalArgument(fmt.Errorf("length %d exceeds the maximum of %d", n, MaxLength))
+	}
+
+	return int(n), nil
+}
+
+// ReadBytes reads a length-prefixed byte slice.
+//
+// Returns:
+//   - []byte: The bytes read.
+//   - error: An error of type *pkg.Err if the input is malformed.
+func (d *Decoder) ReadBytes() ([]byte, error) {
+	n, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+
+	// Grow on demand so that a forged length cannot force a large allocation.
+	data := make([]byte, 0, min(n, 4096))
+
+	for i := 0; i < n; i++ {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return nil, malformed(err)
+		}
+
+		data = append(data, b)
+	}
+
+	return data, nil
+}
+
+// ReadString reads a length-prefixed string.
+//
+// Returns:
+//   - string: The string read.
+//   - error: An error of type *pkg.Err if the input is malformed.
+func (d *Decoder) ReadString() (string, error) {
+	data, err := d.ReadBytes()
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// Decode reads a type ID followed by its payload. This is the method that
+// decoders of containers call for their elements.
+//
+// Returns:
+//   - pkg.Type: The decoded value. Nil if an error occurred.
+//   - error: An error of type *pkg.Err if the input is malformed.
+func (d *Decoder) Decode() (pkg.Type, error) {
+	if d.depth >= MaxDepth {
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("value is nested deeper than %d levels", MaxDepth))
+	}
+
+	id, err := d.ReadUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := lookup_id(id)
+	if !ok {
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("unknown type ID: %d", id))
+	}
+
+	d.depth++
+	defer func() { d.depth-- }()
+
+	return entry.decode(d)
+}
+
+// Encode writes the binary form of the value to the writer.
+//
+// Parameters:
+//   - w: The writer.
+//   - v: The value to encode.
+//
+// Returns:
+//   - error: An error if the value cannot be encoded or written.
+//
+// Nothing is written if the value cannot be encoded.
+func Encode(w io.Writer, v pkg.Type) error {
+	pkg.ThrowIf(w == nil, pkg.NewInvalidCall("w", pkg.NewNilValue()))
+
+	e := &Encoder{}
+
+	err := e.Encode(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(e.buf)
+	return err
+}
+
+// Decode reads one value from the reader.
+//
+// Parameters:
+//   - r: The reader. If it does not implement io.ByteReader, it is buffered
+//     and may therefore be read past the end of the value.
+//
+// Returns:
+//   - pkg.Type: The decoded value. Nil if an error occurred.
+//   - error: An error of type *pkg.Err if the input is malformed.
+//
+// Decode never panics on malformed input: panics raised by the registered
+// decoders are converted to an IllegalArgument error.
+func Decode(r io.Reader) (v pkg.Type, err error) {
+	pkg.ThrowIf(r == nil, pkg.NewInvalidCall("r", pkg.NewNilValue()))
+
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	defer func() {
+		p := recover()
+		if p == nil {
+			return
+		}
+
+		reason, ok := p.(error)
+		if !ok {
+			reason = pkg.NewErrPanic(p)
+		}
+
+		v = nil
+		err = pkg.NewIllegalArgument(reason)
+	}()
+
+	d := &Decoder{
+		r: br,
+	}
+
+	return d.Decode()
+}
+
+// malformed wraps a read error into an IllegalArgument error.
+//
+// Parameters:
+//   - err: The read error.
+//
+// Returns:
+//   - *pkg.Err: The error. Never returns nil.
+func malformed(err error) *pkg.Err {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return pkg.NewIllegalArgument(fmt.Errorf("malformed input: %w", err))
+}
+
+// is_nil checks whether the value is nil or a typed nil pointer.
+//
+// Parameters:
+//   - v: The value to check.
+//
+// Returns:
+//   - bool: True if the value is nil, false otherwise.
+func is_nil(v pkg.Type) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -0,0 +1,244 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	gsd "github.com/PlayerR9/GoSD/slices"
+	"github.com/PlayerR9/GoSD/tree"
+	"github.com/PlayerR9/GoSD/types"
+)
+
+type color int
+
+const (
+	red color = iota
+	green
+)
+
+func (c color) String() string {
+	return [...]string{"red", "green"}[c]
+}
+
+type node struct {
+	value    int
+	children []*node
+}
+
+func (n *node) String() string         { return types.NewInt().WithValue(n.value).String() }
+func (n *node) Clean()                 {}
+func (n *node) Ensure()                {}
+func (n *node) IsLeaf() bool           { return len(n.children) == 0 }
+func (n *node) Child() iter.Seq[*node] { return slices.Values(n.children) }
+func (n *node) BackwardChild() iter.Seq[*node] {
+	return func(yield func(*node) bool) {
+		for _, c := range slices.Backward(n.children) {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+func (n *node) DeepCopy() pkg.Type {
+	c := &node{value: n.value}
+	for _, child := range n.children {
+		c.children = append(c.children, child.DeepCopy().(*node))
+	}
+
+	return c
+}
+
+func (n *node) Equals(other pkg.Type) bool {
+	o, ok := other.(*node)
+	if !ok || n.value != o.value || len(n.children) != len(o.children) {
+		return false
+	}
+
+	for i := range n.children {
+		if !n.children[i].Equals(o.children[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func init() {
+	types.RegisterEnum(nil, "Color", red, green)
+	pkg.Register(nil, "Node", pkg.ScalarKind, nil, func() *node { return &node{} }, nil)
+	tree.RegisterTree[*node](nil)
+
+	RegisterEnum[color](FirstUserID)
+
+	Register(FirstUserID+1, func(e *Encoder, v *node) error {
+		e.WriteVarint(int64(v.value))
+
+		return encode_seq(e, len(v.children), v.Child())
+	}, func(d *Decoder) (*node, error) {
+		value, err := read_int(d)
+		if err != nil {
+			return nil, err
+		}
+
+		children, err := decode_seq[*node](d)
+		if err != nil {
+			return nil, err
+		}
+
+		return &node{value: value, children: children}, nil
+	})
+
+	RegisterTree[*node](FirstUserID + 2)
+}
+
+func samples() []pkg.Type {
+	ints := gsd.NewSlice[*types.Int]().WithValue([]*types.Int{
+		types.NewInt().WithValue(1),
+		types.NewInt().WithValue(-300),
+	})
+
+	mixed := gsd.NewSlice[pkg.Type]().WithValue([]pkg.Type{
+		types.NewBool().WithValue(true),
+		ints,
+		types.NewSet[pkg.Type]().WithValue([]pkg.Type{types.NewWrap("a"), types.NewWrap(2.5)}),
+		types.NewEnum(green),
+	})
+
+	root := &node{value: 1, children: []*node{{value: 2}, {value: 3, children: []*node{{value: 4}}}}}
+
+	return []pkg.Type{
+		types.NewInt().WithValue(42),
+		types.NewBool(),
+		types.NewWrap(true),
+		types.NewWrap(-7),
+		ints,
+		mixed,
+		gsd.NewIndex(mixed).WithMax(3).WithValue(2),
+		tree.NewTree(root),
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, v := range samples() {
+		var buf bytes.Buffer
+
+		err := Encode(&buf, v)
+		if err != nil {
+			t.Fatalf("Encode(%s): %v", v, err)
+		}
+
+		got, err := Decode(&buf)
+		if err != nil {
+			t.Fatalf("Decode(%s): %v", v, err)
+		}
+
+		if _, ok := v.(*gsd.Index[pkg.Type]); ok {
+			// Indexes compare their reference by identity.
+			got_idx := got.(*gsd.Index[pkg.Type])
+			want_idx := v.(*gsd.Index[pkg.Type])
+
+			if got_idx.Value() != want_idx.Value() || got_idx.Max() != want_idx.Max() || !got_idx.Ref().Equals(want_idx.Ref()) {
+				t.Errorf("expected %s, got %s", want_idx, got_idx)
+			}
+
+			continue
+		}
+
+		if !v.Equals(got) {
+			t.Errorf("expected %s, got %s", v, got)
+		}
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	tests := [][]byte{
+		{},
+		{byte(BoolID), 2},
+		{byte(IntSliceID), 2, byte(IntID), 1},
+		{byte(IntSliceID), 1, byte(BoolID), 1},
+		{0x7f},
+		{byte(SliceID), 0xff, 0xff, 0xff, 0xff, 0x0f},
+	}
+
+	for _, data := range tests {
+		_, err := Decode(bytes.NewReader(data))
+
+		var target *pkg.Err
+
+		if !errors.As(err, &target) || target.Code != pkg.IllegalArgument {
+			t.Errorf("Decode(%v): expected an IllegalArgument error, got %v", data, err)
+		}
+	}
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, v := range samples() {
+		var buf bytes.Buffer
+
+		err := Encode(&buf, v)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		v, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			var target *pkg.Err
+
+			if !errors.As(err, &target) {
+				t.Fatalf("expected a *pkg.Err, got %T: %v", err, err)
+			}
+
+			return
+		}
+
+		var buf bytes.Buffer
+
+		err = Encode(&buf, v)
+		if err != nil {
+			t.Fatalf("Encode(%s): %v", v, err)
+		}
+
+		_, err = Decode(&buf)
+		if err != nil {
+			t.Fatalf("re-decoding %s: %v", v, err)
+		}
+	})
+}
+
+func TestRegisterTypeInfo(t *testing.T) {
+	info, _ := pkg.DefaultRegistry.Lookup("Slice[Int]")
+
+	id, ok := IDOf(info.Zero())
+	if !ok || id != IntSliceID {
+		t.Errorf("expected %d, got %d", IntSliceID, id)
+	}
+
+	if _, ok := IDOf(types.NewWrap[int8](1)); ok {
+		t.Errorf("expected no type ID for a type missing from pkg.DefaultRegistry")
+	}
+
+	defer func() {
+		r, _ := recover().(error)
+
+		var err *pkg.Err
+		if !errors.As(r, &err) || err.Code != pkg.InvalidState {
+			t.Errorf("expected an InvalidState error, got %v", r)
+		}
+	}()
+
+	RegisterWrap(FirstUserID+3, func(e *Encoder, v int8) error {
+		e.WriteVarint(int64(v))
+		return nil
+	}, func(d *Decoder) (int8, error) {
+		return 0, nil
+	})
+}
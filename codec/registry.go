@@ -0,0 +1,182 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// EncodeFunc is a function that writes the payload of a value.
+//
+// Parameters:
+//   - e: The encoder.
+//   - v: The value to encode. Never nil.
+//
+// Returns:
+//   - error: An error if the value cannot be encoded.
+type EncodeFunc[T pkg.Type] func(e *Encoder, v T) error
+
+// DecodeFunc is a function that reads the payload of a value.
+//
+// Parameters:
+//   - d: The decoder.
+//
+// Returns:
+//   - T: The decoded value.
+//   - error: An error if the input is malformed.
+type DecodeFunc[T pkg.Type] func(d *Decoder) (T, error)
+
+// entry is a registered type.
+type entry struct {
+	// id is the type ID.
+	id uint64
+
+	// info is the type info of the type in pkg.DefaultRegistry.
+	info *pkg.TypeInfo
+
+	// encode is the type-erased encoder.
+	encode func(e *Encoder, v pkg.Type) error
+
+	// decode is the type-erased decoder.
+	decode func(d *Decoder) (pkg.Type, error)
+}
+
+var (
+	// mu protects by_id and by_info.
+	mu sync.RWMutex
+
+	// by_id maps type IDs to their entry.
+	by_id map[uint64]*entry
+
+	// by_info maps the type infos of pkg.DefaultRegistry to their entry.
+	by_info map[*pkg.TypeInfo]*entry
+)
+
+func init() {
+	by_id = make(map[uint64]*entry)
+	by_info = make(map[*pkg.TypeInfo]*entry)
+
+	register_builtins()
+}
+
+// Register registers the encoder and decoder of a type under the given ID.
+// The type is identified by its type info in pkg.DefaultRegistry, so it must
+// be registered there first; the codec only adds the wire ID.
+//
+// Parameters:
+//   - id: The type ID. Must not be 0 nor already registered.
+//   - enc: The encoder of the payload.
+//   - dec: The decoder of the payload.
+//
+// Panics if the ID or the type is already registered, if T is not registered
+// in pkg.DefaultRegistry, or if enc or dec is nil.
+//
+// IDs below FirstUserID are reserved for the built-in types.
+func Register[T pkg.Type](id uint64, enc EncodeFunc[T], dec DecodeFunc[T]) {
+	pkg.ThrowIf(enc == nil, pkg.NewInvalidCall("enc", pkg.NewNilValue()))
+	pkg.ThrowIf(dec == nil, pkg.NewInvalidCall("dec", pkg.NewNilValue()))
+	pkg.ThrowIf(id == 0, pkg.NewIllegalArgument(errors.New("type ID 0 is reserved")))
+
+	info := pkg.InfoFor[T](nil)
+	pkg.ThrowIf(info == nil, pkg.NewIllegalArgument(fmt.Errorf("type %s cannot be registered", pkg.AnyName)))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	_, ok := by_id[id]
+	pkg.ThrowIf(ok, pkg.NewIllegalArgument(fmt.Errorf("type ID %d is already registered", id)))
+
+	_, ok = by_info[info]
+	pkg.ThrowIf(ok, pkg.NewIllegalArgument(fmt.Errorf("type %s is already registered", info.Name)))
+
+	e := &entry{
+		id:   id,
+		info: info,
+		encode: func(e *Encoder, v pkg.Type) error {
+			return enc(e, v.(T))
+		},
+		decode: func(d *Decoder) (pkg.Type, error) {
+			v, err := dec(d)
+			if err != nil {
+				return nil, err
+			}
+
+			return v, nil
+		},
+	}
+
+	by_id[id] = e
+	by_info[info] = e
+}
+
+// IDOf returns the type ID under which the type of the value is registered.
+//
+// Parameters:
+//   - v: The value.
+//
+// Returns:
+//   - uint64: The type ID.
+//   - bool: True if the type is registered, false otherwise.
+func IDOf(v pkg.Type) (uint64, bool) {
+	if v == nil {
+		return 0, false
+	}
+
+	entry, ok := lookup_value(v)
+	if !ok {
+		return 0, false
+	}
+
+	return entry.id, true
+}
+
+// lookup_id returns the entry registered under the ID.
+func lookup_id(id uint64) (*entry, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	e, ok := by_id[id]
+	return e, ok
+}
+
+// lookup_value returns the entry registered for the type of the value.
+func lookup_value(v pkg.Type) (*entry, bool) {
+	info, ok := pkg.DefaultRegistry.InfoOf(v)
+	if !ok {
+		return nil, false
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	e, ok := by_info[info]
+	return e, ok
+}
+
+// DecodeAs decodes a value and checks that it is of type T.
+//
+// Parameters:
+//   - d: The decoder.
+//
+// Returns:
+//   - T: The decoded value.
+//   - error: An error of type *pkg.Err if the input is malformed or the value
+//     is not of type T.
+func DecodeAs[T pkg.Type](d *Decoder) (T, error) {
+	pkg.ThrowIf(d == nil, pkg.NewInvalidCall("d", pkg.NewNilValue()))
+
+	v, err := d.Decode()
+	if err != nil {
+		return *new(T), err
+	}
+
+	tmp, ok := v.(T)
+	if !ok {
+		return *new(T), pkg.NewIllegalArgument(fmt.Errorf("expected %v, got %T", reflect.TypeFor[T](), v))
+	}
+
+	return tmp, nil
+}
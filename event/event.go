@@ -0,0 +1,138 @@
+package event
+
+import (
+	"fmt"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Change is a change notification. It is one of Set, Appended, Added,
+// Removed, Reset or Batch.
+type Change interface {
+	// String returns a short description of the change.
+	//
+	// Returns:
+	//   - string: The description.
+	String() string
+}
+
+// Set is fired when a value is replaced.
+type Set[T any] struct {
+	// Index is the position of the value in a slice. -1 for scalars.
+	Index int
+
+	// Old is the previous value.
+	Old T
+
+	// New is the new value.
+	New T
+}
+
+// String implements the Change interface.
+func (c Set[T]) String() string {
+	return fmt.Sprintf("Set[index=%d, old=%v, new=%v]", c.Index, c.Old, c.New)
+}
+
+// Appended is fired when an element is appended to a slice.
+type Appended struct {
+	// Index is the position of the new element.
+	Index int
+}
+
+// String implements the Change interface.
+func (c Appended) String() string {
+	return fmt.Sprintf("Appended[index=%d]", c.Index)
+}
+
+// Added is fired when an element is added to a set.
+type Added[T any] struct {
+	// Elem is the new element.
+	Elem T
+}
+
+// String implements the Change interface.
+func (c Added[T]) String() string {
+	return fmt.Sprintf("Added[elem=%v]", c.Elem)
+}
+
+// Removed is fired when an element is removed from a container.
+type Removed[T any] struct {
+	// Index is the position the element had in a slice. -1 for sets.
+	Index int
+
+	// Elem is the removed element.
+	Elem T
+}
+
+// String implements the Change interface.
+func (c Removed[T]) String() string {
+	return fmt.Sprintf("Removed[index=%d, elem=%v]", c.Index, c.Elem)
+}
+
+// Reset is fired when the whole content of a container is reset or replaced.
+type Reset[T any] struct {
+	// Old is the previous content. When the content is replaced by
+	// WithValue, these are copies of the previous elements, which have been
+	// cleaned.
+	Old []T
+
+	// New is the new content. Empty after a reset.
+	New []T
+}
+
+// String implements the Change interface.
+func (c Reset[T]) String() string {
+	return fmt.Sprintf("Reset[old=%v, new=%v]", c.Old, c.New)
+}
+
+// Batch is fired in place of the changes made within a batch, when there is
+// more than one of them.
+type Batch struct {
+	// Changes are the changes, in the order they were made.
+	Changes []Change
+}
+
+// String implements the Change interface.
+func (c Batch) String() string {
+	return fmt.Sprintf("Batch%v", c.Changes)
+}
+
+// Reverter is implemented by types that can revert the changes they notify.
+type Reverter interface {
+	// Subscribe subscribes to the changes of the type.
+	//
+	// Parameters:
+	//   - fn: The callback.
+	//
+	// Returns:
+	//   - func(): The function that unsubscribes the callback.
+	Subscribe(fn func(Change)) func()
+
+	// Revert applies the inverse of a change the type notified. Changes must
+	// be reverted in the reverse order they were made. Batches are not
+	// accepted; revert their changes one by one instead.
+	//
+	// Parameters:
+	//   - c: The change to revert.
+	//
+	// Returns:
+	//   - Change: The change that reverts the revert, that is, the one that
+	//     redoes c.
+	//
+	// Throws:
+	//   - *IllegalArgument: If the change cannot be reverted by the type.
+	Revert(c Change) Change
+}
+
+// NewNotRevertible creates the error thrown when a type is asked to revert a
+// change it does not know.
+//
+// Parameters:
+//   - c: The change.
+//   - type_: The type asked to revert the change.
+//
+// Returns:
+//   - *pkg.Err: The new error. Never returns nil.
+func NewNotRevertible(c Change, type_ any) *pkg.Err {
+	return pkg.NewIllegalArgument(fmt.Errorf("change %v cannot be reverted by %T", c, type_))
+}
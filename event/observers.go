@@ -0,0 +1,128 @@
+package event
+
+import "github.com/PlayerR9/GoSD/pkg"
+
+// subscription is a subscribed callback.
+type subscription struct {
+	// id identifies the subscription.
+	id int
+
+	// fn is the callback.
+	fn func(Change)
+}
+
+// Observers is the list of callbacks subscribed to a value. Types keep a
+// pointer to it, allocated on the first subscription, and call Notify after
+// every mutation. It is not safe for concurrent use, like the types it is
+// attached to.
+type Observers struct {
+	// subs are the subscriptions, in subscription order.
+	subs []subscription
+
+	// next_id is the ID of the next subscription.
+	next_id int
+
+	// depth is the nesting depth of the batches in progress.
+	depth int
+
+	// pending are the changes made within the current batch.
+	pending []Change
+}
+
+// Subscribe adds a callback to the observers.
+//
+// Parameters:
+//   - fn: The callback. It is called synchronously after every change.
+//
+// Returns:
+//   - func(): The function that removes the callback. Calling it more than
+//     once is a no-op.
+//
+// Panics if fn is nil.
+func (o *Observers) Subscribe(fn func(Change)) func() {
+	pkg.ThrowIf(o == nil, pkg.NewInvalidState("o", pkg.NewNilValue()))
+	pkg.ThrowIf(fn == nil, pkg.NewInvalidCall("fn", pkg.NewNilValue()))
+
+	id := o.next_id
+	o.next_id++
+
+	o.subs = append(o.subs, subscription{
+		id: id,
+		fn: fn,
+	})
+
+	unsubscribe := func() {
+		for i, sub := range o.subs {
+			if sub.id == id {
+				o.subs = append(o.subs[:i:i], o.subs[i+1:]...)
+				return
+			}
+		}
+	}
+
+	return unsubscribe
+}
+
+// Notify sends the change to every callback, or records it if a batch is in
+// progress.
+//
+// Parameters:
+//   - c: The change.
+//
+// Does nothing if o is nil.
+func (o *Observers) Notify(c Change) {
+	if o == nil {
+		return
+	}
+
+	if o.depth > 0 {
+		o.pending = append(o.pending, c)
+		return
+	}
+
+	// Copy so that callbacks can unsubscribe while being notified.
+	subs := append([]subscription(nil), o.subs...)
+
+	for _, sub := range subs {
+		sub.fn(c)
+	}
+}
+
+// Batch calls fn and merges the changes it makes into a single notification:
+// the change itself if there is only one, a Batch otherwise. Batches can be
+// nested; only the outermost one notifies.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+//
+// Does nothing but calling fn if o is nil. The changes are still notified if
+// fn panics.
+func (o *Observers) Batch(fn func()) {
+	pkg.ThrowIf(fn == nil, pkg.NewInvalidCall("fn", pkg.NewNilValue()))
+
+	if o == nil {
+		fn()
+		return
+	}
+
+	o.depth++
+
+	defer func() {
+		o.depth--
+
+		if o.depth > 0 || len(o.pending) == 0 {
+			return
+		}
+
+		pending := o.pending
+		o.pending = nil
+
+		if len(pending) == 1 {
+			o.Notify(pending[0])
+		} else {
+			o.Notify(Batch{Changes: pending})
+		}
+	}()
+
+	fn()
+}
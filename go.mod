@@ -1,3 +1,5 @@
 module github.com/PlayerR9/GoSD
 
 go 1.23.0
+
+require golang.org/x/text v0.24.0
@@ -0,0 +1,314 @@
+package history
+
+import (
+	"errors"
+
+	"github.com/PlayerR9/GoSD/event"
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// entry is a recorded change.
+type entry struct {
+	// target is the value the change was made to.
+	target event.Reverter
+
+	// change is the change.
+	change event.Change
+}
+
+// step is a group of changes that are undone and redone together, in the
+// order they were made.
+type step []entry
+
+// History records the changes made to the tracked values and undoes or redoes
+// them by applying their inverse, without taking snapshots. Changes made
+// within a transaction are undone and redone as one step. It is not safe for
+// concurrent use, like the values it tracks.
+//
+// Removed elements are kept alive by the history; they must not be cleaned
+// while they can still be redone. Cleaning a tracked value drops its
+// subscriptions, so it stops being tracked.
+type History struct {
+	// depth is the maximum number of steps that can be undone. 0 means no
+	// limit.
+	depth int
+
+	// undo are the steps that can be undone, the last one on top.
+	undo []step
+
+	// redo are the steps that can be redone, the last one on top.
+	redo []step
+
+	// txs are the open transactions, the innermost one on top.
+	txs []step
+
+	// applying is whether the history is reverting changes itself, in which
+	// case the notified changes are not recorded.
+	applying bool
+
+	// unsubs are the functions that stop tracking the values.
+	unsubs []func()
+}
+
+// New creates a new history.
+//
+// Parameters:
+//   - depth: The maximum number of steps that can be undone. 0 means no
+//     limit.
+//
+// Returns:
+//   - *History: The new history. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If depth is negative.
+func New(depth int) *History {
+	pkg.ThrowIf(depth < 0, pkg.NewIllegalArgument(errors.New("depth must not be negative")))
+
+	return &History{
+		depth: depth,
+	}
+}
+
+// Track starts recording the changes made to the value.
+//
+// Parameters:
+//   - v: The value to track.
+//
+// Panics if v is nil.
+func (h *History) Track(v event.Reverter) {
+	pkg.ThrowIf(h == nil, pkg.NewInvalidState("h", pkg.NewNilValue()))
+	pkg.ThrowIf(v == nil, pkg.NewInvalidCall("v", pkg.NewNilValue()))
+
+	unsub := v.Subscribe(func(c event.Change) {
+		h.record(v, c)
+	})
+
+	h.unsubs = append(h.unsubs, unsub)
+}
+
+// Close stops tracking every value and forgets every recorded change. The
+// open transactions are dropped without being rolled back.
+func (h *History) Close() {
+	if h == nil {
+		return
+	}
+
+	for _, unsub := range h.unsubs {
+		unsub()
+	}
+
+	h.unsubs = nil
+	h.undo = nil
+	h.redo = nil
+	h.txs = nil
+}
+
+// record records a change notified by a tracked value.
+//
+// Parameters:
+//   - target: The value.
+//   - c: The change.
+func (h *History) record(target event.Reverter, c event.Change) {
+	if h.applying {
+		return
+	}
+
+	var entries step
+
+	if batch, ok := c.(event.Batch); ok {
+		for _, c := range batch.Changes {
+			entries = append(entries, entry{target: target, change: c})
+		}
+	} else {
+		entries = step{{target: target, change: c}}
+	}
+
+	if len(h.txs) > 0 {
+		top := len(h.txs) - 1
+		h.txs[top] = append(h.txs[top], entries...)
+
+		return
+	}
+
+	h.push(entries)
+	h.redo = nil
+}
+
+// push pushes a step on the undo stack, dropping the oldest one if the depth
+// is exceeded.
+//
+// Parameters:
+//   - s: The step.
+func (h *History) push(s step) {
+	if len(s) == 0 {
+		return
+	}
+
+	h.undo = append(h.undo, s)
+
+	if h.depth > 0 && len(h.undo) > h.depth {
+		h.undo[0] = nil
+		h.undo = h.undo[1:]
+	}
+}
+
+// revert reverts the changes of a step, the last one first.
+//
+// Parameters:
+//   - s: The step.
+//
+// Returns:
+//   - step: The step that reverts the revert.
+func (h *History) revert(s step) step {
+	h.applying = true
+	defer func() {
+		h.applying = false
+	}()
+
+	inverse := make(step, 0, len(s))
+
+	for i := len(s) - 1; i >= 0; i-- {
+		e := s[i]
+
+		c := e.target.Revert(e.change)
+
+		inverse = append(inverse, entry{target: e.target, change: c})
+	}
+
+	return inverse
+}
+
+// Begin opens a transaction. The changes made until the matching Commit or
+// Rollback form a single step. Transactions can be nested.
+func (h *History) Begin() {
+	pkg.ThrowIf(h == nil, pkg.NewInvalidState("h", pkg.NewNilValue()))
+
+	h.txs = append(h.txs, step{})
+}
+
+// Commit closes the innermost transaction and keeps its changes. The changes
+// of a nested transaction become part of the enclosing one.
+//
+// Throws:
+//   - *InvalidState: If no transaction is open.
+func (h *History) Commit() {
+	tx := h.pop_tx()
+
+	if len(h.txs) > 0 {
+		top := len(h.txs) - 1
+		h.txs[top] = append(h.txs[top], tx...)
+
+		return
+	}
+
+	if len(tx) > 0 {
+		h.push(tx)
+		h.redo = nil
+	}
+}
+
+// Rollback closes the innermost transaction and reverts its changes.
+//
+// Throws:
+//   - *InvalidState: If no transaction is open.
+func (h *History) Rollback() {
+	tx := h.pop_tx()
+
+	h.revert(tx)
+}
+
+// pop_tx removes the innermost transaction.
+//
+// Returns:
+//   - step: The changes made within the transaction.
+//
+// Throws:
+//   - *InvalidState: If no transaction is open.
+func (h *History) pop_tx() step {
+	pkg.ThrowIf(h == nil, pkg.NewInvalidState("h", pkg.NewNilValue()))
+	pkg.ThrowIf(len(h.txs) == 0, pkg.NewInvalidState("h", errors.New("no transaction is open")))
+
+	top := len(h.txs) - 1
+
+	tx := h.txs[top]
+	h.txs[top] = nil
+	h.txs = h.txs[:top]
+
+	return tx
+}
+
+// InTransaction checks whether a transaction is open.
+//
+// Returns:
+//   - bool: True if a transaction is open, false otherwise.
+func (h *History) InTransaction() bool {
+	return h != nil && len(h.txs) > 0
+}
+
+// CanUndo checks whether there is a step to undo.
+//
+// Returns:
+//   - bool: True if there is a step to undo, false otherwise.
+func (h *History) CanUndo() bool {
+	return h != nil && len(h.undo) > 0
+}
+
+// CanRedo checks whether there is a step to redo.
+//
+// Returns:
+//   - bool: True if there is a step to redo, false otherwise.
+func (h *History) CanRedo() bool {
+	return h != nil && len(h.redo) > 0
+}
+
+// Undo reverts the last step.
+//
+// Returns:
+//   - bool: True if a step was undone, false if there was none.
+//
+// Throws:
+//   - *InvalidState: If a transaction is open.
+func (h *History) Undo() bool {
+	pkg.ThrowIf(h == nil, pkg.NewInvalidState("h", pkg.NewNilValue()))
+	pkg.ThrowIf(len(h.txs) > 0, pkg.NewInvalidState("h", errors.New("cannot undo within a transaction")))
+
+	if len(h.undo) == 0 {
+		return false
+	}
+
+	top := len(h.undo) - 1
+
+	s := h.undo[top]
+	h.undo[top] = nil
+	h.undo = h.undo[:top]
+
+	h.redo = append(h.redo, h.revert(s))
+
+	return true
+}
+
+// Redo applies again the last undone step.
+//
+// Returns:
+//   - bool: True if a step was redone, false if there was none.
+//
+// Throws:
+//   - *InvalidState: If a transaction is open.
+func (h *History) Redo() bool {
+	pkg.ThrowIf(h == nil, pkg.NewInvalidState("h", pkg.NewNilValue()))
+	pkg.ThrowIf(len(h.txs) > 0, pkg.NewInvalidState("h", errors.New("cannot redo within a transaction")))
+
+	if len(h.redo) == 0 {
+		return false
+	}
+
+	top := len(h.redo) - 1
+
+	s := h.redo[top]
+	h.redo[top] = nil
+	h.redo = h.redo[:top]
+
+	h.push(h.revert(s))
+
+	return true
+}
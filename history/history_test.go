@@ -0,0 +1,97 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/slices"
+	"github.com/PlayerR9/GoSD/types"
+)
+
+func TestUndoRedo(t *testing.T) {
+	s := slices.NewSlice[*types.Int]()
+	set := types.NewSet[*types.Int]()
+
+	h := New(0)
+	h.Track(s)
+	h.Track(set)
+
+	s.Append(types.NewInt().WithValue(1))
+	s.Append(types.NewInt().WithValue(2))
+	set.Add(types.NewInt().WithValue(3))
+	s.DeleteFirst()
+
+	if s.String() != "Slice[2]" || set.String() != "Set[3]" {
+		t.Fatalf("unexpected state: %s %s", s, set)
+	}
+
+	for h.Undo() {
+	}
+
+	if s.String() != "Slice[]" || set.String() != "Set[]" {
+		t.Fatalf("expected empty values, got %s %s", s, set)
+	}
+
+	for h.Redo() {
+	}
+
+	if s.String() != "Slice[2]" || set.String() != "Set[3]" {
+		t.Fatalf("expected the changes to be redone, got %s %s", s, set)
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	s := slices.NewSlice[*types.Int]().WithValue([]*types.Int{types.NewInt().WithValue(1)})
+
+	h := New(0)
+	h.Track(s)
+
+	h.Begin()
+	s.Append(types.NewInt().WithValue(2))
+
+	h.Begin()
+	s.WithValue([]*types.Int{types.NewInt().WithValue(9)})
+	h.Rollback()
+
+	s.Append(types.NewInt().WithValue(3))
+	h.Commit()
+
+	if s.String() != "Slice[1, 2, 3]" {
+		t.Fatalf("expected Slice[1, 2, 3], got %s", s)
+	}
+
+	if !h.Undo() {
+		t.Fatalf("expected a step to undo")
+	}
+
+	if s.String() != "Slice[1]" {
+		t.Fatalf("expected the transaction to be undone as one step, got %s", s)
+	}
+
+	if h.CanUndo() {
+		t.Errorf("expected nothing left to undo")
+	}
+}
+
+func TestDepth(t *testing.T) {
+	i := types.NewInt()
+
+	h := New(2)
+	h.Track(i)
+
+	i.Set(1)
+	i.Set(2)
+	i.Set(3)
+
+	for h.Undo() {
+	}
+
+	if i.Value() != 1 {
+		t.Errorf("expected 1, got %d", i.Value())
+	}
+
+	i.Set(5)
+
+	if h.CanRedo() {
+		t.Errorf("expected a new change to clear the redo stack")
+	}
+}
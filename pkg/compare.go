@@ -0,0 +1,42 @@
+package pkg
+
+import (
+	"fmt"
+)
+
+// Comparer is implemented by types whose values are ordered. Containers such
+// as types.Pair use it to order their components.
+type Comparer[T any] interface {
+	// Compare compares the value with another one.
+	//
+	// Parameters:
+	//   - other: The other value.
+	//
+	// Returns:
+	//   - int: -1 if the value is less than other, 0 if they are equal and +1
+	//     if it is greater.
+	Compare(other T) int
+}
+
+// Compare compares two values of a type that implements Comparer.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//
+// Returns:
+//   - int: -1 if a is less than b, 0 if they are equal and +1 if a is greater.
+//
+// Throws:
+//   - *IllegalArgument: If T does not implement Comparer[T].
+//
+// Panics if a or b is nil.
+func Compare[T Type](a, b T) int {
+	Ensure(false, a)
+	Ensure(false, b)
+
+	c, ok := any(a).(Comparer[T])
+	ThrowIf(!ok, NewIllegalArgument(fmt.Errorf("%T is not ordered", a)))
+
+	return c.Compare(b)
+}
@@ -0,0 +1,102 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+var (
+	// debug is whether the debug mode is enabled.
+	debug atomic.Bool
+)
+
+// SetDebug enables or disables the debug mode. In debug mode, values are
+// poisoned by Clean and any later use of them throws an InvalidState error.
+// The debug mode is enabled from the start when building with the
+// "gosd_debug" tag.
+//
+// Parameters:
+//   - enabled: Whether the debug mode is enabled.
+func SetDebug(enabled bool) {
+	debug.Store(enabled)
+}
+
+// IsDebug checks whether the debug mode is enabled.
+//
+// Returns:
+//   - bool: True if the debug mode is enabled, false otherwise.
+func IsDebug() bool {
+	return debug.Load()
+}
+
+// ErrUsedAfterClean is the reason of the error thrown when a cleaned value is
+// used in debug mode.
+var ErrUsedAfterClean error = errors.New("used after Clean")
+
+// Poison marks a value as cleaned when the debug mode is enabled. Types embed
+// it as an unexported field, call Mark from Clean and Check from every other
+// method. The zero value is a live value.
+type Poison struct {
+	// stack is the stack of the Clean call. Nil if the value is alive.
+	stack *string
+}
+
+// Mark poisons the value if the debug mode is enabled. It records the stack of
+// its caller, which is expected to be the Clean method.
+func (p *Poison) Mark() {
+	if !IsDebug() || p.stack != nil {
+		return
+	}
+
+	stack := CallerStack(1)
+	p.stack = &stack
+}
+
+// RecycleKey is the key that Pool passes to Recycler.Recycle. Only this
+// package holds a valid key, so that only a pool can revive a cleaned value.
+type RecycleKey struct {
+	// _ makes the key non-empty, so that the keys have distinct addresses.
+	_ byte
+}
+
+// recycle_key is the only valid recycle key.
+var recycle_key *RecycleKey = &RecycleKey{}
+
+// Revive makes a poisoned value usable again. Recycle methods call it with the
+// key given by Pool so that cleaned values can be reused.
+//
+// Parameters:
+//   - key: The key given by Pool.
+//
+// Throws:
+//   - *InvalidCall: If key is not the one given by Pool.
+func (p *Poison) Revive(key *RecycleKey) {
+	ThrowIf(key != recycle_key, NewInvalidCall("key", errors.New("only a Pool can revive a cleaned value")))
+
+	p.stack = nil
+}
+
+// IsPoisoned checks whether the value was cleaned in debug mode.
+//
+// Returns:
+//   - bool: True if the value is poisoned, false otherwise.
+func (p Poison) IsPoisoned() bool {
+	return p.stack != nil
+}
+
+// Check throws if the value is poisoned.
+//
+// Parameters:
+//   - de_name: The name of the data entity that is checked.
+//
+// Throws:
+//   - *InvalidState: If the value was cleaned in debug mode. The message
+//     includes the stack of the Clean call.
+func (p Poison) Check(de_name string) {
+	if p.stack == nil {
+		return
+	}
+
+	Throw(NewInvalidState(de_name, fmt.Errorf("%w; cleaned at:\n%s", ErrUsedAfterClean, *p.stack)))
+}
@@ -0,0 +1,7 @@
+//go:build gosd_debug
+
+package pkg
+
+func init() {
+	SetDebug(true)
+}
@@ -40,6 +40,26 @@ const (
 	// Example:
 	// 	foo.Call(42)
 	IllegalArgument
+
+	// Overflow happens when the result of an arithmetic operation does not fit
+	// in its type.
+	//
+	// Example:
+	// 	NewNum[int8](127).Add(1)
+	Overflow
+
+	// DivisionByZero happens when a value is divided by zero.
+	//
+	// Example:
+	// 	NewNum(42).Div(0)
+	DivisionByZero
+
+	// LossyConversion happens when a value cannot be converted to another type
+	// without changing it.
+	//
+	// Example:
+	// 	ConvertNum[int8](NewNum(300))
+	LossyConversion
 )
 
 // NewNilComparison creates a new error with the NilComparison error code.
@@ -112,3 +132,42 @@ func NewIllegalArgument(msg error) *Err {
 		Msg:  msg,
 	}
 }
+
+// NewOverflow creates a new error with the Overflow error code.
+//
+// Parameters:
+//   - msg: The reason for the error.
+//
+// Returns:
+//   - *Err: The new error. Never returns nil.
+func NewOverflow(msg error) *Err {
+	return &Err{
+		Code: Overflow,
+		Msg:  msg,
+	}
+}
+
+// NewDivisionByZero creates a new error with the DivisionByZero error code.
+//
+// Returns:
+//   - *Err: The new error. Never returns nil.
+func NewDivisionByZero() *Err {
+	return &Err{
+		Code: DivisionByZero,
+		Msg:  errors.New("division by zero"),
+	}
+}
+
+// NewLossyConversion creates a new error with the LossyConversion error code.
+//
+// Parameters:
+//   - msg: The reason for the error.
+//
+// Returns:
+//   - *Err: The new error. Never returns nil.
+func NewLossyConversion(msg error) *Err {
+	return &Err{
+		Code: LossyConversion,
+		Msg:  msg,
+	}
+}
@@ -12,11 +12,15 @@ func _() {
 	_ = x[InvalidCall-1]
 	_ = x[NilValue-2]
 	_ = x[InvalidState-3]
+	_ = x[IllegalArgument-4]
+	_ = x[Overflow-5]
+	_ = x[DivisionByZero-6]
+	_ = x[LossyConversion-7]
 }
 
-const _ErrorCode_name = "NilComparisonInvalidCallNilValueInvalidState"
+const _ErrorCode_name = "NilComparisonInvalidCallNilValueInvalidStateIllegalArgumentOverflowDivisionByZeroLossyConversion"
 
-var _ErrorCode_index = [...]uint8{0, 13, 24, 32, 44}
+var _ErrorCode_index = [...]uint8{0, 13, 24, 32, 44, 59, 67, 81, 96}
 
 func (i ErrorCode) String() string {
 	if i < 0 || i >= ErrorCode(len(_ErrorCode_index)-1) {
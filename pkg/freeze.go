@@ -0,0 +1,53 @@
+package pkg
+
+import "errors"
+
+// ErrFrozen is the reason of the error thrown when a frozen value is mutated.
+var ErrFrozen error = errors.New("value is frozen")
+
+// Freezer is implemented by types that can be made read-only. Cleaning a
+// frozen value throws an InvalidState error; containers skip their frozen
+// elements when cleaned.
+type Freezer interface {
+	// Freeze makes the type read-only. Any later call to a mutator throws
+	// an InvalidState error. Containers also freeze their elements.
+	Freeze()
+
+	// IsFrozen checks whether the type is read-only.
+	//
+	// Returns:
+	//   - bool: True if the type is frozen, false otherwise.
+	IsFrozen() bool
+}
+
+// Freeze freezes the type if it implements Freezer.
+//
+// Parameters:
+//   - type_: The type to freeze. Nil values are ignored.
+//
+// Returns:
+//   - bool: True if the type implements Freezer, false otherwise.
+func Freeze(type_ Type) bool {
+	if is_nil(type_) {
+		return false
+	}
+
+	f, ok := type_.(Freezer)
+	if ok {
+		f.Freeze()
+	}
+
+	return ok
+}
+
+// NewFrozen creates a new error with the InvalidState error code, thrown when
+// a frozen value is mutated.
+//
+// Parameters:
+//   - de_name: The name of the data entity that is frozen.
+//
+// Returns:
+//   - *Err: The new error. Never returns nil.
+func NewFrozen(de_name string) *Err {
+	return NewInvalidState(de_name, ErrFrozen)
+}
@@ -0,0 +1,13 @@
+package pkg
+
+// Hasher is implemented by types that can be hashed. Containers such as
+// types.Map use the hash to find the values equal to a given one without
+// comparing it to all of them.
+type Hasher interface {
+	// Hash returns the hash of the value. Values that are equal according to
+	// Equals must have the same hash.
+	//
+	// Returns:
+	//   - uint64: The hash.
+	Hash() uint64
+}
@@ -0,0 +1,354 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrParse is an error that occurs at a given position of a parsed text.
+type ErrParse struct {
+	// Line is the 1-based line of the error.
+	Line int
+
+	// Column is the 1-based column, in runes, of the error.
+	Column int
+
+	// Reason is the reason for the error.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// Message: "line {line}, column {column}: {reason}"
+func (e ErrParse) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, Error(e.Reason))
+}
+
+// Unwrap implements errors.Unwrap interface.
+func (e ErrParse) Unwrap() error {
+	return e.Reason
+}
+
+// Scanner reads a text rune by rune while keeping track of the line and
+// column of the next rune.
+type Scanner struct {
+	// input is the text being scanned.
+	input string
+
+	// pos is the byte offset of the next rune.
+	pos int
+
+	// line is the 1-based line of the next rune.
+	line int
+
+	// column is the 1-based column of the next rune.
+	column int
+}
+
+// NewScanner creates a new scanner over the given text.
+//
+// Parameters:
+//   - input: The text to scan.
+//
+// Returns:
+//   - *Scanner: The new scanner. Never returns nil.
+func NewScanner(input string) *Scanner {
+	return &Scanner{
+		input:  input,
+		pos:    0,
+		line:   1,
+		column: 1,
+	}
+}
+
+// IsDone checks whether the whole text has been consumed.
+//
+// Returns:
+//   - bool: True if there is nothing left to scan, false otherwise.
+func (s Scanner) IsDone() bool {
+	return s.pos >= len(s.input)
+}
+
+// Position returns the position of the next rune.
+//
+// Returns:
+//   - int: The 1-based line.
+//   - int: The 1-based column.
+func (s Scanner) Position() (int, int) {
+	return s.line, s.column
+}
+
+// Peek returns the next rune without consuming it.
+//
+// Returns:
+//   - rune: The next rune. utf8.RuneError if there is none.
+//   - bool: True if there is a next rune, false otherwise.
+func (s Scanner) Peek() (rune, bool) {
+	if s.pos >= len(s.input) {
+		return utf8.RuneError, false
+	}
+
+	r, _ := utf8.DecodeRuneInString(s.input[s.pos:])
+
+	return r, true
+}
+
+// Next consumes the next rune.
+//
+// Returns:
+//   - rune: The consumed rune. utf8.RuneError if there is none.
+//   - bool: True if a rune was consumed, false otherwise.
+func (s *Scanner) Next() (rune, bool) {
+	if s.pos >= len(s.input) {
+		return utf8.RuneError, false
+	}
+
+	r, size := utf8.DecodeRuneInString(s.input[s.pos:])
+	s.pos += size
+
+	if r == '\n' {
+		s.line++
+		s.column = 1
+	} else {
+		s.column++
+	}
+
+	return r, true
+}
+
+// HasPrefix checks whether the remaining text starts with the given literal.
+//
+// Parameters:
+//   - lit: The literal to check.
+//
+// Returns:
+//   - bool: True if the remaining text starts with the literal, false otherwise.
+func (s Scanner) HasPrefix(lit string) bool {
+	return strings.HasPrefix(s.input[s.pos:], lit)
+}
+
+// Accept consumes the given literal if the remaining text starts with it.
+//
+// Parameters:
+//   - lit: The literal to consume.
+//
+// Returns:
+//   - bool: True if the literal was consumed, false otherwise.
+func (s *Scanner) Accept(lit string) bool {
+	if !s.HasPrefix(lit) {
+		return false
+	}
+
+	for i := 0; i < utf8.RuneCountInString(lit); i++ {
+		s.Next()
+	}
+
+	return true
+}
+
+// Expect consumes the given literal.
+//
+// Parameters:
+//   - lit: The literal to consume.
+//
+// Returns:
+//   - error: An error of type *Err if the remaining text does not start with
+//     the literal.
+func (s *Scanner) Expect(lit string) error {
+	if s.Accept(lit) {
+		return nil
+	}
+
+	return s.Errorf("expected %q, got %s", lit, s.describe())
+}
+
+// SkipSpaces consumes spaces and tabs. Newlines are not consumed.
+func (s *Scanner) SkipSpaces() {
+	for {
+		r, ok := s.Peek()
+		if !ok || (r != ' ' && r != '\t') {
+			return
+		}
+
+		s.Next()
+	}
+}
+
+// SkipWhitespace consumes spaces, tabs and newlines.
+func (s *Scanner) SkipWhitespace() {
+	for {
+		r, ok := s.Peek()
+		if !ok || (r != ' ' && r != '\t' && r != '\n' && r != '\r') {
+			return
+		}
+
+		s.Next()
+	}
+}
+
+// Until consumes runes up to, but excluding, the first rune that satisfies
+// the given predicate.
+//
+// Parameters:
+//   - stop: The predicate.
+//
+// Returns:
+//   - string: The consumed text.
+func (s *Scanner) Until(stop func(r rune) bool) string {
+	start := s.pos
+
+	for {
+		r, ok := s.Peek()
+		if !ok || stop(r) {
+			break
+		}
+
+		s.Next()
+	}
+
+	return s.input[start:s.pos]
+}
+
+// Errorf creates an error at the position of the next rune.
+//
+// Parameters:
+//   - format: The format of the reason, in accordance with fmt.Printf.
+//   - args: The arguments of the format.
+//
+// Returns:
+//   - *Err: An IllegalArgument error wrapping an *ErrParse. Never returns nil.
+func (s Scanner) Errorf(format string, args ...any) *Err {
+	return s.ErrorAt(s.line, s.column, fmt.Errorf(format, args...))
+}
+
+// ErrorAt creates an error at the given position.
+//
+// Parameters:
+//   - line: The 1-based line.
+//   - column: The 1-based column.
+//   - reason: The reason for the error.
+//
+// Returns:
+//   - *Err: An IllegalArgument error wrapping an *ErrParse. Never returns nil.
+func (s Scanner) ErrorAt(line, column int, reason error) *Err {
+	return NewIllegalArgument(&ErrParse{
+		Line:   line,
+		Column: column,
+		Reason: reason,
+	})
+}
+
+// describe returns a short description of the next rune, for error messages.
+func (s Scanner) describe() string {
+	r, ok := s.Peek()
+	if !ok {
+		return "end of input"
+	}
+
+	return fmt.Sprintf("%q", r)
+}
+
+// ParseFunc is a function that parses a value from the scanner. It must stop
+// right after the value so that the caller can parse what follows.
+//
+// Parameters:
+//   - s: The scanner. Never nil.
+//
+// Returns:
+//   - T: The parsed value.
+//   - error: An error, preferably created with Scanner.Errorf, if the text is
+//     not a valid value.
+type ParseFunc[T Type] func(s *Scanner) (T, error)
+
+// ScanList parses a comma-separated list of elements terminated by ']'. The
+// opening bracket must already be consumed. Whitespace, including newlines, is
+// allowed around the elements.
+//
+// Parameters:
+//   - s: The scanner.
+//   - elem: The function that parses the elements.
+//
+// Returns:
+//   - []T: The parsed elements.
+//   - error: An error if the text is not a valid list.
+func ScanList[T Type](s *Scanner, elem ParseFunc[T]) ([]T, error) {
+	var elems []T
+
+	s.SkipWhitespace()
+
+	if s.Accept("]") {
+		return elems, nil
+	}
+
+	for {
+		s.SkipWhitespace()
+
+		v, err := elem(s)
+		if err != nil {
+			return nil, err
+		}
+
+		elems = append(elems, v)
+
+		s.SkipWhitespace()
+
+		if s.Accept("]") {
+			return elems, nil
+		}
+
+		err = s.Expect(",")
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Parse parses a value from the whole text.
+//
+// Parameters:
+//   - text: The text to parse.
+//   - fn: The function that parses the value.
+//
+// Returns:
+//   - T: The parsed value.
+//   - error: An IllegalArgument error wrapping an *ErrParse if the text is not a
+//     valid value or has trailing characters.
+//
+// A single trailing newline is accepted.
+func Parse[T Type](text string, fn ParseFunc[T]) (T, error) {
+	ThrowIf(fn == nil, NewInvalidCall("fn", NewNilValue()))
+
+	s := NewScanner(text)
+
+	res, err := fn(s)
+	if err != nil {
+		return *new(T), err
+	}
+
+	s.Accept("\n")
+
+	if !s.IsDone() {
+		return *new(T), s.Errorf("unexpected trailing %s", s.describe())
+	}
+
+	return res, nil
+}
+
+// AsParseError returns the position of a parse error.
+//
+// Parameters:
+//   - err: The error to check.
+//
+// Returns:
+//   - *ErrParse: The parse error. Nil if err does not wrap an *ErrParse.
+func AsParseError(err error) *ErrParse {
+	var target *ErrParse
+
+	if !errors.As(err, &target) {
+		return nil
+	}
+
+	return target
+}
@@ -0,0 +1,321 @@
+package pkg
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Resetter is implemented by types that can be brought back to their zero
+// state.
+type Resetter interface {
+	// Reset resets the type to its zero state.
+	Reset()
+}
+
+// Recycler is implemented by types that a Pool can reuse after Clean. Unlike
+// Reset, which throws on a value cleaned in debug mode, Recycle revives it.
+type Recycler interface {
+	// Recycle revives the cleaned value and resets it to its zero state.
+	//
+	// Parameters:
+	//   - key: The key to pass to Poison.Revive. Only a Pool holds it.
+	Recycle(key *RecycleKey)
+}
+
+// Pool is a pool of reusable values built on sync.Pool and aware of the
+// Clean lifecycle: Put cleans the value and Get returns a reset one.
+type Pool[T Type] struct {
+	// pool is the underlying pool.
+	pool sync.Pool
+
+	// new_fn creates a new value.
+	new_fn func() T
+
+	// debug is the debug tracker. Nil if the debug mode is disabled.
+	debug *pool_tracker
+}
+
+// pool_tracker tracks the values of a pool in debug mode.
+type pool_tracker struct {
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// free is the list of values in the pool. The debug mode does not use
+	// sync.Pool so that values are never dropped behind its back.
+	free []any
+
+	// outstanding maps the values taken from the pool to the stack of the Get
+	// call.
+	outstanding map[any]string
+
+	// problems is the list of double returns seen so far.
+	problems []error
+}
+
+// NewPool creates a new pool.
+//
+// Parameters:
+//   - new_fn: The function that creates a new value when the pool is empty.
+//
+// Returns:
+//   - *Pool[T]: The new pool. Never returns nil.
+//
+// Panics if new_fn is nil.
+func NewPool[T Type](new_fn func() T) *Pool[T] {
+	ThrowIf(new_fn == nil, NewInvalidCall("new_fn", NewNilValue()))
+
+	p := &Pool[T]{
+		new_fn: new_fn,
+	}
+
+	p.pool.New = func() any {
+		return new_fn()
+	}
+
+	return p
+}
+
+// WithDebug enables or disables the debug mode of the pool. In debug mode,
+// the pool remembers which values were taken and not returned, and which
+// values were returned twice; see Report.
+//
+// Parameters:
+//   - enabled: Whether the debug mode is enabled.
+//
+// Returns:
+//   - *Pool[T]: The pool. Never returns nil.
+//
+// Switching the mode forgets every value currently in the pool.
+func (p *Pool[T]) WithDebug(enabled bool) *Pool[T] {
+	ThrowIf(p == nil, NewInvalidState("p", NewNilValue()))
+
+	if !enabled {
+		p.debug = nil
+	} else if p.debug == nil {
+		p.debug = &pool_tracker{
+			outstanding: make(map[any]string),
+		}
+	}
+
+	return p
+}
+
+// IsDebug checks whether the debug mode of the pool is enabled.
+//
+// Returns:
+//   - bool: True if the debug mode is enabled, false otherwise.
+func (p *Pool[T]) IsDebug() bool {
+	return p != nil && p.debug != nil
+}
+
+// Get takes a value from the pool or creates a new one.
+//
+// Returns:
+//   - T: A value in its reset state.
+func (p *Pool[T]) Get() T {
+	ThrowIf(p == nil, NewInvalidState("p", NewNilValue()))
+
+	if p.debug == nil {
+		return p.pool.Get().(T)
+	}
+
+	t := p.debug
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var v T
+
+	if len(t.free) > 0 {
+		v = t.free[len(t.free)-1].(T)
+		t.free[len(t.free)-1] = nil
+		t.free = t.free[:len(t.free)-1]
+	} else {
+		v = p.new_fn()
+	}
+
+	if is_trackable(v) {
+		t.outstanding[v] = CallerStack(1)
+	}
+
+	return v
+}
+
+// Put cleans the value, recycles it if it implements Recycler or else resets
+// it if it implements Resetter, and returns it to the pool. The value must not be used afterwards.
+//
+// Parameters:
+//   - v: The value to return. Nil values are ignored.
+func (p *Pool[T]) Put(v T) {
+	ThrowIf(p == nil, NewInvalidState("p", NewNilValue()))
+
+	if is_nil(v) {
+		return
+	}
+
+	if p.debug == nil {
+		recycle(v)
+		p.pool.Put(v)
+
+		return
+	}
+
+	t := p.debug
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if is_trackable(v) {
+		_, ok := t.outstanding[v]
+		if !ok && contains_value(t.free, v) {
+			err := NewInvalidState("v", fmt.Errorf("value %p returned twice to the pool; second return at:\n%s", any(v), CallerStack(1)))
+			t.problems = append(t.problems, err)
+
+			return
+		}
+
+		delete(t.outstanding, v)
+	}
+
+	recycle(v)
+	t.free = append(t.free, v)
+}
+
+// Report returns the problems found by the debug mode: one error per value
+// that was taken and never returned, and one per value returned twice.
+//
+// Returns:
+//   - []error: The problems, as InvalidState errors. Nil if the debug mode is
+//     disabled or if there is no problem.
+func (p *Pool[T]) Report() []error {
+	if !p.IsDebug() {
+		return nil
+	}
+
+	t := p.debug
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	problems := make([]error, 0, len(t.outstanding)+len(t.problems))
+	problems = append(problems, t.problems...)
+
+	for v, stack := range t.outstanding {
+		err := NewInvalidState("v", fmt.Errorf("value %p was never returned to the pool; taken at:\n%s", v, stack))
+		problems = append(problems, err)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return problems
+}
+
+// Outstanding returns the number of values taken from the pool and not yet
+// returned. Only meaningful in debug mode.
+//
+// Returns:
+//   - int: The number of outstanding values. 0 if the debug mode is disabled.
+func (p *Pool[T]) Outstanding() int {
+	if !p.IsDebug() {
+		return 0
+	}
+
+	p.debug.mu.Lock()
+	defer p.debug.mu.Unlock()
+
+	return len(p.debug.outstanding)
+}
+
+var (
+	// shared_pools maps the Go types to their shared pool.
+	shared_pools sync.Map
+)
+
+// SharedPool returns the process-wide pool of T, creating it with new_fn on
+// first use. It is meant for generic types that cannot declare one pool
+// variable per instantiation.
+//
+// Parameters:
+//   - new_fn: The function that creates a new value.
+//
+// Returns:
+//   - *Pool[T]: The shared pool. Never returns nil.
+func SharedPool[T Type](new_fn func() T) *Pool[T] {
+	key := reflect.TypeFor[T]()
+
+	p, ok := shared_pools.Load(key)
+	if !ok {
+		p, _ = shared_pools.LoadOrStore(key, NewPool(new_fn))
+	}
+
+	return p.(*Pool[T])
+}
+
+// CallerStack returns the stack of the caller.
+//
+// Parameters:
+//   - skip: The number of frames to skip above the caller of CallerStack.
+//
+// Returns:
+//   - string: The stack, one "function\n\tfile:line" entry per frame.
+func CallerStack(skip int) string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+2, pcs)
+
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var builder strings.Builder
+
+	for {
+		frame, more := frames.Next()
+
+		fmt.Fprintf(&builder, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+
+		if !more {
+			break
+		}
+	}
+
+	return builder.String()
+}
+
+// recycle cleans and resets the value.
+func recycle(v Type) {
+	v.Clean()
+
+	switch v := v.(type) {
+	case Recycler:
+		v.Recycle(recycle_key)
+	case Resetter:
+		v.Reset()
+	}
+}
+
+// is_nil checks whether the value is nil or a typed nil pointer.
+func is_nil(v Type) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// is_trackable checks whether the value can be used as a map key.
+func is_trackable(v Type) bool {
+	return reflect.TypeOf(v).Comparable()
+}
+
+// contains_value checks whether the slice contains the very same value.
+func contains_value(values []any, v any) bool {
+	for _, elem := range values {
+		if elem == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,361 @@
+package pkg
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+//go:generate stringer -type=Kind
+
+// Kind is the shape of a registered type.
+type Kind int
+
+const (
+	// ScalarKind is the kind of types that hold a single value, such as Int.
+	ScalarKind Kind = iota
+
+	// ContainerKind is the kind of types that hold elements, such as Slice.
+	ContainerKind
+
+	// TreeKind is the kind of trees.
+	TreeKind
+)
+
+// AnyName is the name under which the Type interface itself is known, as in
+// "Slice[Type]".
+const AnyName string = "Type"
+
+// TypeInfo describes a registered type.
+type TypeInfo struct {
+	// Name is the stable name of the type, such as "Int" or "Slice[Bool]".
+	Name string
+
+	// Kind is the kind of the type.
+	Kind Kind
+
+	// Elem is the element type of containers and trees. Nil for scalars and
+	// for containers of any Type.
+	Elem *TypeInfo
+
+	// Type is the Go type of the values.
+	Type reflect.Type
+
+	// Zero creates a new zero value.
+	Zero func() Type
+
+	// Factory creates a value from a plain Go value, such as the ones decoded
+	// from a JSON or YAML configuration (bool, float64, string, []any, ...).
+	Factory func(value any) (Type, error)
+}
+
+// Registry maps stable names to the types that can be created at runtime.
+// It is safe for concurrent use and meant to be shared by the serialization
+// layers, which can rely on TypeInfo.Name as a stable identifier.
+type Registry struct {
+	// mu protects by_name and by_type.
+	mu sync.RWMutex
+
+	// by_name maps names to their type info.
+	by_name map[string]*TypeInfo
+
+	// by_type maps Go types to their type info.
+	by_type map[reflect.Type]*TypeInfo
+}
+
+// DefaultRegistry is the registry in which the built-in types register
+// themselves.
+var DefaultRegistry *Registry = NewRegistry()
+
+// NewRegistry creates a new empty registry.
+//
+// Returns:
+//   - *Registry: The new registry. Never returns nil.
+func NewRegistry() *Registry {
+	return &Registry{
+		by_name: make(map[string]*TypeInfo),
+		by_type: make(map[reflect.Type]*TypeInfo),
+	}
+}
+
+// Register registers a type under the given name.
+//
+// Parameters:
+//   - r: The registry. If nil, DefaultRegistry is used.
+//   - name: The stable name of the type.
+//   - kind: The kind of the type.
+//   - elem: The element type of containers and trees. May be nil.
+//   - zero: The function that creates a zero value.
+//   - factory: The function that creates a value from a plain Go value. If
+//     nil, values can only be created with Zero.
+//
+// Returns:
+//   - *TypeInfo: The registered type info. Never returns nil.
+//
+// Panics if the name or the type is already registered, or if zero is nil.
+func Register[T Type](r *Registry, name string, kind Kind, elem *TypeInfo, zero func() T, factory func(value any) (T, error)) *TypeInfo {
+	ThrowIf(zero == nil, NewInvalidCall("zero", NewNilValue()))
+	ThrowIf(name == "", NewIllegalArgument(fmt.Errorf("name must not be empty")))
+
+	if r == nil {
+		r = DefaultRegistry
+	}
+
+	info := &TypeInfo{
+		Name: name,
+		Kind: kind,
+		Elem: elem,
+		Type: reflect.TypeFor[T](),
+		Zero: func() Type {
+			return zero()
+		},
+	}
+
+	if factory != nil {
+		info.Factory = func(value any) (Type, error) {
+			v, err := factory(value)
+			if err != nil {
+				return nil, err
+			}
+
+			return v, nil
+		}
+	} else {
+		info.Factory = func(value any) (Type, error) {
+			return nil, NewInvalidCall(name, fmt.Errorf("type %s has no factory", name))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.by_name[name]
+	ThrowIf(ok, NewIllegalArgument(fmt.Errorf("name %q is already registered", name)))
+
+	_, ok = r.by_type[info.Type]
+	ThrowIf(ok, NewIllegalArgument(fmt.Errorf("type %v is already registered", info.Type)))
+
+	r.by_name[name] = info
+	r.by_type[info.Type] = info
+
+	return info
+}
+
+// InfoFor returns the type info of T.
+//
+// Parameters:
+//   - r: The registry. If nil, DefaultRegistry is used.
+//
+// Returns:
+//   - *TypeInfo: The type info. Nil if T is the Type interface itself.
+//
+// Panics with an InvalidState error if T is not registered.
+func InfoFor[T Type](r *Registry) *TypeInfo {
+	if r == nil {
+		r = DefaultRegistry
+	}
+
+	rt := reflect.TypeFor[T]()
+	if rt == reflect.TypeFor[Type]() {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.by_type[rt]
+	ThrowIf(!ok, NewInvalidState(rt.String(), fmt.Errorf("type is not registered")))
+
+	return info
+}
+
+// NameOf returns the name under which the element type is known.
+//
+// Parameters:
+//   - elem: The element type info. Nil stands for any Type.
+//
+// Returns:
+//   - string: The name. AnyName if elem is nil.
+func NameOf(elem *TypeInfo) string {
+	if elem == nil {
+		return AnyName
+	}
+
+	return elem.Name
+}
+
+// Lookup returns the type registered under the given name.
+//
+// Parameters:
+//   - name: The name of the type.
+//
+// Returns:
+//   - *TypeInfo: The type info. Nil if no type is registered under that name.
+//   - bool: True if the type is registered, false otherwise.
+func (r *Registry) Lookup(name string) (*TypeInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.by_name[name]
+	return info, ok
+}
+
+// InfoOf returns the type info of the given instance.
+//
+// Parameters:
+//   - v: The instance.
+//
+// Returns:
+//   - *TypeInfo: The type info. Nil if the type of v is not registered.
+//   - bool: True if the type is registered, false otherwise.
+func (r *Registry) InfoOf(v Type) (*TypeInfo, bool) {
+	if v == nil {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.by_type[reflect.TypeOf(v)]
+	return info, ok
+}
+
+// TypeName returns the name under which the type of the given instance is
+// registered.
+//
+// Parameters:
+//   - v: The instance.
+//
+// Returns:
+//   - string: The name. Empty if the type of v is not registered.
+//   - bool: True if the type is registered, false otherwise.
+func (r *Registry) TypeName(v Type) (string, bool) {
+	info, ok := r.InfoOf(v)
+	if !ok {
+		return "", false
+	}
+
+	return info.Name, true
+}
+
+// New creates a new zero value of the type registered under the given name.
+//
+// Parameters:
+//   - name: The name of the type.
+//
+// Returns:
+//   - Type: The new value. Nil if an error occurred.
+//   - error: An IllegalArgument error if no type is registered under that name.
+func (r *Registry) New(name string) (Type, error) {
+	info, ok := r.Lookup(name)
+	if !ok {
+		return nil, NewIllegalArgument(fmt.Errorf("unknown type name: %q", name))
+	}
+
+	return info.Zero(), nil
+}
+
+// Make creates a value of the type registered under the given name from a
+// plain Go value.
+//
+// Parameters:
+//   - name: The name of the type.
+//   - value: The plain Go value.
+//
+// Returns:
+//   - Type: The new value. Nil if an error occurred.
+//   - error: An IllegalArgument error if no type is registered under that
+//     name, or any error returned by the factory.
+func (r *Registry) Make(name string, value any) (Type, error) {
+	info, ok := r.Lookup(name)
+	if !ok {
+		return nil, NewIllegalArgument(fmt.Errorf("unknown type name: %q", name))
+	}
+
+	return info.Factory(value)
+}
+
+// Names returns the names of all the registered types, in sorted order.
+//
+// Returns:
+//   - []string: The names.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.by_name))
+	for name := range r.by_name {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// MakeElem creates an element of a container from a plain Go value.
+//
+// Parameters:
+//   - elem: The element type info. Nil stands for any Type, in which case
+//     value must already be a Type.
+//   - value: The plain Go value.
+//
+// Returns:
+//   - T: The new element.
+//   - error: An IllegalArgument error if the value cannot be converted.
+func MakeElem[T Type](elem *TypeInfo, value any) (T, error) {
+	var v Type
+
+	if elem == nil {
+		tmp, ok := value.(Type)
+		if !ok {
+			return *new(T), NewIllegalArgument(fmt.Errorf("expected a Type, got %T", value))
+		}
+
+		v = tmp
+	} else {
+		tmp, err := elem.Factory(value)
+		if err != nil {
+			return *new(T), err
+		}
+
+		v = tmp
+	}
+
+	res, ok := v.(T)
+	if !ok {
+		return *new(T), NewIllegalArgument(fmt.Errorf("expected %v, got %T", reflect.TypeFor[T](), v))
+	}
+
+	return res, nil
+}
+
+// MakeElems creates the elements of a container from a plain Go slice.
+//
+// Parameters:
+//   - elem: The element type info. See MakeElem.
+//   - value: The plain Go slice, such as a []any.
+//
+// Returns:
+//   - []T: The new elements.
+//   - error: An IllegalArgument error if the value cannot be converted.
+func MakeElems[T Type](elem *TypeInfo, value any) ([]T, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, NewIllegalArgument(fmt.Errorf("expected a slice, got %T", value))
+	}
+
+	elems := make([]T, 0, rv.Len())
+
+	for i := 0; i < rv.Len(); i++ {
+		v, err := MakeElem[T](elem, rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		elems = append(elems, v)
+	}
+
+	return elems, nil
+}
@@ -71,19 +71,27 @@ func Ensure(allow_nil bool, type_ Type) {
 	type_.Ensure()
 }
 
-// Clean cleans up the type.
+// Clean cleans up the type. Frozen values are left as they are, since they
+// may be shared; this lets containers clean their elements without throwing.
 //
 // Parameters:
 //   - type_: The type to clean up.
 func Clean(type_ Type) {
-	if type_ == nil {
+	if type_ == nil || is_frozen(type_) {
 		return
 	}
 
 	type_.Clean()
 }
 
-// CleanSlice cleans up the slice. Remember to set to nil after use.
+// is_frozen checks whether the type implements Freezer and is frozen.
+func is_frozen(type_ Type) bool {
+	f, ok := type_.(Freezer)
+	return ok && !is_nil(type_) && f.IsFrozen()
+}
+
+// CleanSlice cleans up the slice. Remember to set to nil after use. Frozen
+// elements are left as they are, as with Clean.
 //
 // Parameters:
 //   - slice: The slice to clean up.
@@ -92,7 +100,7 @@ func Clean(type_ Type) {
 //   - []T: The cleaned slice.
 func CleanSlice[T Type](slice []T) []T {
 	for i := 0; i < len(slice); i++ {
-		slice[i].Clean()
+		Clean(slice[i])
 	}
 
 	return slice[:0:0]
@@ -1,10 +1,8 @@
 package slices
 
 import (
-	"errors"
 	"fmt"
 	"iter"
-	"strconv"
 	"strings"
 
 	"github.com/PlayerR9/GoSD/pkg"
@@ -12,31 +10,27 @@ import (
 
 // Index is an index.
 type Index[T pkg.Type] struct {
-	// value is the index value.
-	value int
-
-	// max is the index max value. -1 indicates that the max value is the
-	// size of the reference.
-	max int
+	// pos is the position of the index in the slice.
+	pos Position
 
 	// ref is the slice reference.
 	ref *Slice[T]
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
 }
 
 // String implements the fmt.Stringer interface.
 func (idx *Index[T]) String() string {
-	var builder strings.Builder
-
-	builder.WriteString("Index[value=")
-	builder.WriteString(strconv.Itoa(idx.value))
-	builder.WriteString(", max=")
+	idx.poison.Check("idx")
 
-	if idx.max == -1 {
-		builder.WriteString("+Inf")
-	} else {
-		builder.WriteString(strconv.Itoa(idx.max))
-	}
+	var builder strings.Builder
 
+	builder.WriteString("Index[")
+	builder.WriteString(idx.pos.String())
 	builder.WriteString(", ref=")
 	fmt.Fprintf(&builder, "%p", idx.ref)
 	builder.WriteString("]")
@@ -46,16 +40,18 @@ func (idx *Index[T]) String() string {
 
 // DeepCopy implements the pkg.Type interface.
 func (idx *Index[T]) DeepCopy() pkg.Type {
+	idx.poison.Check("idx")
+
 	return &Index[T]{
-		value: idx.value,
-		ref:   idx.ref,
-		max:   idx.max,
+		pos: idx.pos,
+		ref: idx.ref,
 	}
 }
 
 // Ensure implements the pkg.Type interface.
 func (idx *Index[T]) Ensure() {
 	pkg.ThrowIf(idx == nil, pkg.NewInvalidState("idx", pkg.NewNilValue()))
+	idx.poison.Check("idx")
 	pkg.ThrowIf(idx.ref == nil, pkg.NewInvalidState("idx.ref", pkg.NewNilValue()))
 }
 
@@ -65,9 +61,13 @@ func (idx *Index[T]) Clean() {
 		return
 	}
 
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
 	if idx.ref != nil {
 		idx.ref = nil
 	}
+
+	idx.poison.Mark()
 }
 
 // Equals implements the pkg.Type interface.
@@ -79,7 +79,7 @@ func (idx *Index[T]) Equals(other pkg.Type) bool {
 
 	switch other := other.(type) {
 	case *Index[T]:
-		return idx.value == other.value && idx.ref == other.ref
+		return idx.pos.value == other.pos.value && idx.ref == other.ref
 	default:
 		return false
 	}
@@ -98,9 +98,7 @@ func NewIndex[T pkg.Type](slice *Slice[T]) *Index[T] {
 	pkg.Ensure(false, slice)
 
 	return &Index[T]{
-		value: 0,
-		ref:   slice,
-		max:   -1,
+		ref: slice,
 	}
 }
 
@@ -115,12 +113,9 @@ func NewIndex[T pkg.Type](slice *Slice[T]) *Index[T] {
 // Panics if the slice is nil.
 func (idx *Index[T]) WithValue(value int) *Index[T] {
 	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
 
-	max := pkg.OrElse(idx.max == -1, idx.ref.Size(), idx.max)
-
-	pkg.ThrowIf(value < 0 || value >= max, pkg.NewIllegalArgument(errors.New("value must be less than slice size")))
-
-	idx.value = value
+	idx.pos.Set(value, idx.ref.Size())
 
 	return idx
 }
@@ -136,10 +131,9 @@ func (idx *Index[T]) WithValue(value int) *Index[T] {
 // Panics if the slice is nil.
 func (idx *Index[T]) WithMax(max int) *Index[T] {
 	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
 
-	pkg.ThrowIf(max < 0 || max > idx.ref.Size(), pkg.NewIllegalArgument(errors.New("max must be less than slice size")))
-
-	idx.max = max
+	idx.pos.SetMax(max, idx.ref.Size())
 
 	return idx
 }
@@ -152,8 +146,9 @@ func (idx *Index[T]) WithMax(max int) *Index[T] {
 // Panics if the slice is nil.
 func (idx *Index[T]) WithoutMax() *Index[T] {
 	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
 
-	idx.max = -1
+	idx.pos.ClearMax()
 
 	return idx
 }
@@ -163,7 +158,9 @@ func (idx *Index[T]) WithoutMax() *Index[T] {
 // Returns:
 //   - int: The index value.
 func (idx Index[T]) Value() int {
-	return idx.value
+	idx.poison.Check("idx")
+
+	return idx.pos.value
 }
 
 // Max returns the index max value.
@@ -171,7 +168,30 @@ func (idx Index[T]) Value() int {
 // Returns:
 //   - int: The index max value.
 func (idx Index[T]) Max() int {
-	return pkg.OrElse(idx.max == -1, idx.ref.Size(), idx.max)
+	idx.poison.Check("idx")
+
+	return idx.pos.Limit(idx.ref.Size())
+}
+
+// HasMax checks whether the index has an explicit max value.
+//
+// Returns:
+//   - bool: True if the max value was set with WithMax, false if the max value
+//     follows the size of the reference.
+func (idx Index[T]) HasMax() bool {
+	idx.poison.Check("idx")
+
+	return idx.pos.has_max
+}
+
+// Ref returns the slice the index refers to.
+//
+// Returns:
+//   - *Slice[T]: The slice reference. Nil if the index was cleaned.
+func (idx Index[T]) Ref() *Slice[T] {
+	idx.poison.Check("idx")
+
+	return idx.ref
 }
 
 // Set sets the index value.
@@ -180,12 +200,23 @@ func (idx Index[T]) Max() int {
 //   - value: The new value.
 func (idx *Index[T]) Set(value int) {
 	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
 
-	max := pkg.OrElse(idx.max == -1, idx.ref.Size(), idx.max)
+	idx.pos.Set(value, idx.ref.Size())
+}
 
-	pkg.ThrowIf(value < 0 || value >= max, pkg.NewIllegalArgument(errors.New("value must be less than slice size")))
+// Freeze implements the pkg.Freezer interface.
+//
+// The slice the index refers to is not frozen.
+func (idx *Index[T]) Freeze() {
+	pkg.Ensure(false, idx)
+
+	idx.frozen = true
+}
 
-	idx.value = value
+// IsFrozen implements the pkg.Freezer interface.
+func (idx Index[T]) IsFrozen() bool {
+	return idx.frozen
 }
 
 // Each creates an iterator that iterates over the index from the current value up to the maximum value.
@@ -195,27 +226,13 @@ func (idx *Index[T]) Set(value int) {
 func (idx Index[T]) Each() iter.Seq[*Index[T]] {
 	pkg.Ensure(false, &idx)
 
-	var fn func(yield func(*Index[T]) bool)
-
-	if idx.max == -1 {
-		fn = func(yield func(*Index[T]) bool) {
-			for idx.value < idx.ref.Size() {
-				if !yield(&idx) {
-					return
-				}
-
-				idx.value++
-			}
-		}
-	} else {
-		fn = func(yield func(*Index[T]) bool) {
-			for idx.value < idx.max {
-				if !yield(&idx) {
-					return
-				}
-
-				idx.value++
+	fn := func(yield func(*Index[T]) bool) {
+		for idx.pos.value < idx.pos.Limit(idx.ref.Size()) {
+			if !yield(&idx) {
+				return
 			}
+
+			idx.pos.value++
 		}
 	}
 
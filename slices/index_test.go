@@ -0,0 +1,55 @@
+package slices_test
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/slices"
+	"github.com/PlayerR9/GoSD/types"
+)
+
+func TestPosition(t *testing.T) {
+	var p slices.Position
+
+	if p.HasMax() || p.Limit(3) != 3 || p.String() != "value=0, max=+Inf" {
+		t.Fatalf("unexpected zero position: %s", p)
+	}
+
+	for p.Next(3) {
+	}
+
+	if p.Value() != 2 || !p.Prev() || p.Value() != 1 {
+		t.Errorf("expected to move up to 2 and back to 1, got %s", p)
+	}
+
+	p.SetMax(2, 3)
+
+	if p.Next(3) || p.Limit(3) != 2 || p.String() != "value=1, max=2" {
+		t.Errorf("expected to stop before the max value, got %s", p)
+	}
+
+	p.ClearMax()
+
+	if p.HasMax() || !p.Next(3) {
+		t.Errorf("expected the max value to follow the size, got %s", p)
+	}
+}
+
+func TestSliceIndex(t *testing.T) {
+	s := slices.NewSlice[*types.Int]().WithValue([]*types.Int{types.NewInt(), types.NewInt(), types.NewInt()})
+
+	var values []int
+
+	for idx := range s.Index().Each() {
+		values = append(values, idx.Value())
+	}
+
+	if len(values) != 3 || values[2] != 2 {
+		t.Errorf("expected to iterate over 0, 1 and 2, got %v", values)
+	}
+
+	idx := slices.NewIndex(s).WithMax(2)
+
+	if idx.Max() != 2 || idx.WithoutMax().Max() != 3 {
+		t.Errorf("unexpected max values for %s", idx)
+	}
+}
@@ -0,0 +1,51 @@
+package slices
+
+import "github.com/PlayerR9/GoSD/pkg"
+
+// Parse parses a slice from its String() form.
+//
+// Parameters:
+//   - text: The text to parse.
+//   - elem: The function that parses the elements.
+//
+// Returns:
+//   - *Slice[T]: The parsed slice. Nil if an error occurred.
+//   - error: An IllegalArgument error wrapping a *pkg.ErrParse if the text is
+//     not a valid slice.
+//
+// Example:
+//
+//	slice, err := Parse("Slice[Set[1], Set[]]", types.ScanSet(types.ScanInt))
+func Parse[T pkg.Type](text string, elem pkg.ParseFunc[T]) (*Slice[T], error) {
+	return pkg.Parse(text, ScanSlice(elem))
+}
+
+// ScanSlice creates a function that parses a slice in the form printed by
+// Slice.String(). Use it to parse slices nested in other containers.
+//
+// Parameters:
+//   - elem: The function that parses the elements.
+//
+// Returns:
+//   - pkg.ParseFunc[*Slice[T]]: The parse function. Never returns nil.
+//
+// Panics if elem is nil.
+func ScanSlice[T pkg.Type](elem pkg.ParseFunc[T]) pkg.ParseFunc[*Slice[T]] {
+	pkg.ThrowIf(elem == nil, pkg.NewInvalidCall("elem", pkg.NewNilValue()))
+
+	fn := func(s *pkg.Scanner) (*Slice[T], error) {
+		err := s.Expect("Slice[")
+		if err != nil {
+			return nil, err
+		}
+
+		elems, err := pkg.ScanList(s, elem)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSlice[T]().WithValue(elems), nil
+	}
+
+	return fn
+}
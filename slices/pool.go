@@ -0,0 +1,12 @@
+package slices
+
+import "github.com/PlayerR9/GoSD/pkg"
+
+// SlicePool returns the shared pool of slices of T. Values returned by Get are
+// empty and Put cleans the elements of the slice.
+//
+// Returns:
+//   - *pkg.Pool[*Slice[T]]: The pool. Never returns nil.
+func SlicePool[T pkg.Type]() *pkg.Pool[*Slice[T]] {
+	return pkg.SharedPool(NewSlice[T])
+}
@@ -0,0 +1,141 @@
+package slices
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Position is a position in a sequence, bounded by a max value that is either
+// set explicitly or follows the size of the sequence. It holds the logic
+// shared by the indexes of the sequences, such as Index and
+// types.OrderedIndex, which give the size of their reference on each call.
+//
+// The zero value is the first position, with a max value that follows the
+// size of the sequence.
+type Position struct {
+	// value is the position value.
+	value int
+
+	// max is the explicit max value. Only meaningful if has_max is true.
+	max int
+
+	// has_max is whether the max value was set explicitly.
+	has_max bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The position is written as "value=1, max=3", or "value=1, max=+Inf" when the
+// max value follows the size of the sequence.
+func (p Position) String() string {
+	var builder strings.Builder
+
+	builder.WriteString("value=")
+	builder.WriteString(strconv.Itoa(p.value))
+	builder.WriteString(", max=")
+
+	if p.has_max {
+		builder.WriteString(strconv.Itoa(p.max))
+	} else {
+		builder.WriteString("+Inf")
+	}
+
+	return builder.String()
+}
+
+// Value returns the position value.
+//
+// Returns:
+//   - int: The position value.
+func (p Position) Value() int {
+	return p.value
+}
+
+// HasMax checks whether the position has an explicit max value.
+//
+// Returns:
+//   - bool: True if the max value was set with SetMax, false if the max value
+//     follows the size of the sequence.
+func (p Position) HasMax() bool {
+	return p.has_max
+}
+
+// Limit returns the max value of the position.
+//
+// Parameters:
+//   - size: The size of the sequence.
+//
+// Returns:
+//   - int: The explicit max value, if any. Otherwise, size.
+func (p Position) Limit(size int) int {
+	return pkg.OrElse(p.has_max, p.max, size)
+}
+
+// Set sets the position value.
+//
+// Parameters:
+//   - value: The new value.
+//   - size: The size of the sequence.
+//
+// Throws:
+//   - *IllegalArgument: If value is negative or not less than the max value.
+func (p *Position) Set(value, size int) {
+	pkg.ThrowIf(value < 0 || value >= p.Limit(size), pkg.NewIllegalArgument(errors.New("value must be less than the max value")))
+
+	p.value = value
+}
+
+// SetMax sets the max value explicitly.
+//
+// Parameters:
+//   - max: The max value.
+//   - size: The size of the sequence.
+//
+// Throws:
+//   - *IllegalArgument: If max is negative or greater than size.
+func (p *Position) SetMax(max, size int) {
+	pkg.ThrowIf(max < 0 || max > size, pkg.NewIllegalArgument(errors.New("max must not be greater than the size")))
+
+	p.max = max
+	p.has_max = true
+}
+
+// ClearMax makes the max value follow the size of the sequence.
+func (p *Position) ClearMax() {
+	p.max = 0
+	p.has_max = false
+}
+
+// Next moves the position forward, if it stays less than the max value.
+//
+// Parameters:
+//   - size: The size of the sequence.
+//
+// Returns:
+//   - bool: True if the position moved, false otherwise.
+func (p *Position) Next(size int) bool {
+	if p.value+1 >= p.Limit(size) {
+		return false
+	}
+
+	p.value++
+
+	return true
+}
+
+// Prev moves the position backward, if it is not the first one.
+//
+// Returns:
+//   - bool: True if the position moved, false otherwise.
+func (p *Position) Prev() bool {
+	if p.value == 0 {
+		return false
+	}
+
+	p.value--
+
+	return true
+}
@@ -0,0 +1,59 @@
+package slices
+
+import "github.com/PlayerR9/GoSD/pkg"
+
+func init() {
+	RegisterSlice[pkg.Type](nil)
+	RegisterIndex[pkg.Type](nil)
+}
+
+// RegisterSlice registers *Slice[T] under the name "Slice[{elem}]", where
+// {elem} is the name of T. The factory accepts any Go slice whose elements the
+// factory of T accepts.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Panics if T is not registered in r, unless T is pkg.Type itself.
+func RegisterSlice[T pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	elem := pkg.InfoFor[T](r)
+
+	zero := func() *Slice[T] {
+		return NewSlice[T]()
+	}
+
+	factory := func(value any) (*Slice[T], error) {
+		elems, err := pkg.MakeElems[T](elem, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSlice[T]().WithValue(elems), nil
+	}
+
+	return pkg.Register(r, "Slice["+pkg.NameOf(elem)+"]", pkg.ContainerKind, elem, zero, factory)
+}
+
+// RegisterIndex registers *Index[T] under the name "Index[{elem}]", where
+// {elem} is the name of T. The zero value is an index into a new empty slice
+// and there is no factory, since an index cannot exist without its slice.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Panics if T is not registered in r, unless T is pkg.Type itself.
+func RegisterIndex[T pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	elem := pkg.InfoFor[T](r)
+
+	zero := func() *Index[T] {
+		return NewIndex(NewSlice[T]())
+	}
+
+	return pkg.Register[*Index[T]](r, "Index["+pkg.NameOf(elem)+"]", pkg.ScalarKind, nil, zero, nil)
+}
@@ -6,6 +6,7 @@ import (
 	"iter"
 	"strings"
 
+	"github.com/PlayerR9/GoSD/event"
 	"github.com/PlayerR9/GoSD/pkg"
 )
 
@@ -13,10 +14,22 @@ import (
 type Slice[T pkg.Type] struct {
 	// values is the slice values.
 	values []T
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
 }
 
 // String implements the fmt.Stringer interface.
 func (s *Slice[T]) String() string {
+	s.poison.Check("s")
+
 	var builder strings.Builder
 
 	builder.WriteString("Slice[")
@@ -38,6 +51,8 @@ func (s *Slice[T]) DeepCopy() pkg.Type {
 		return nil
 	}
 
+	s.poison.Check("s")
+
 	slice := make([]T, 0, len(s.values))
 
 	for _, v := range s.values {
@@ -57,6 +72,8 @@ func (s *Slice[T]) DeepCopy() pkg.Type {
 // Ensure implements the pkg.Type interface.
 func (s *Slice[T]) Ensure() {
 	pkg.ThrowIf(s == nil, pkg.NewInvalidState("s", pkg.NewNilValue()))
+
+	s.poison.Check("s")
 }
 
 // Clean implements the pkg.Type interface.
@@ -65,8 +82,13 @@ func (s *Slice[T]) Clean() {
 		return
 	}
 
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
 	s.values = pkg.CleanSlice(s.values)
 	s.values = nil
+
+	s.poison.Mark()
+	s.observers = nil
 }
 
 // Equals implements the pkg.Type interface.
@@ -118,9 +140,23 @@ func (s *Slice[T]) WithValue(slice []T) *Slice[T] {
 		}
 	}
 
+	s.poison.Check("s")
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	var old []T
+
+	if s.observers != nil {
+		// The old elements are cleaned below; the observers get copies.
+		old = s.DeepCopy().(*Slice[T]).values
+	}
+
 	s.values = pkg.CleanSlice(s.values)
 	s.values = slice
 
+	if s.observers != nil {
+		s.observers.Notify(event.Reset[T]{Old: old, New: s.values})
+	}
+
 	return s
 }
 
@@ -129,6 +165,8 @@ func (s *Slice[T]) WithValue(slice []T) *Slice[T] {
 // Returns:
 //   - bool: True if the slice is empty, false otherwise.
 func (s Slice[T]) IsEmpty() bool {
+	s.poison.Check("s")
+
 	return len(s.values) == 0
 }
 
@@ -137,6 +175,8 @@ func (s Slice[T]) IsEmpty() bool {
 // Returns:
 //   - int: The number of elements in the slice.
 func (s Slice[T]) Size() int {
+	s.poison.Check("s")
+
 	return len(s.values)
 }
 
@@ -148,8 +188,13 @@ func (s Slice[T]) Size() int {
 // Panics if the slice is nil.
 func (s *Slice[T]) Append(elem T) {
 	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
 
 	s.values = append(s.values, elem)
+
+	if s.observers != nil {
+		s.observers.Notify(event.Appended{Index: len(s.values) - 1})
+	}
 }
 
 // Merge merges the given slice into the slice.
@@ -160,12 +205,24 @@ func (s *Slice[T]) Append(elem T) {
 // Panics if the slice is nil.
 func (s *Slice[T]) Merge(slice *Slice[T]) {
 	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
 
 	if slice == nil {
 		return
 	}
 
+	start := len(s.values)
 	s.values = append(s.values, slice.values...)
+
+	if s.observers == nil {
+		return
+	}
+
+	s.observers.Batch(func() {
+		for i := start; i < len(s.values); i++ {
+			s.observers.Notify(event.Appended{Index: i})
+		}
+	})
 }
 
 // Reset removes all elements from the slice.
@@ -174,10 +231,29 @@ func (s *Slice[T]) Reset() {
 		return
 	}
 
+	s.poison.Check("s")
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	var old []T
+
+	if s.observers != nil {
+		old = append(old, s.values...)
+	}
+
 	for i := 0; i < len(s.values); i++ {
 		s.values[i] = *new(T)
 	}
 	s.values = s.values[:0]
+
+	if s.observers != nil {
+		s.observers.Notify(event.Reset[T]{Old: old})
+	}
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (s *Slice[T]) Recycle(key *pkg.RecycleKey) {
+	s.poison.Revive(key)
+	s.Reset()
 }
 
 // Each returns an iterator that iterates over all elements in the slice.
@@ -185,6 +261,8 @@ func (s *Slice[T]) Reset() {
 // Returns:
 //   - iter.Seq[T]: The iterator. Never returns nil.
 func (s Slice[T]) Each() iter.Seq[T] {
+	s.poison.Check("s")
+
 	fn := func(yield func(T) bool) {
 		for _, elem := range s.values {
 			if !yield(elem) {
@@ -204,8 +282,7 @@ func (s *Slice[T]) Index() *Index[T] {
 	pkg.Ensure(false, s)
 
 	return &Index[T]{
-		value: 0,
-		ref:   s,
+		ref: s,
 	}
 }
 
@@ -224,7 +301,7 @@ func (s *Slice[T]) ElemAt(i *Index[T]) T {
 
 	pkg.ThrowIf(i.ref != s, fmt.Errorf("index refers to a different slice: %p", i.ref))
 
-	return s.values[i.value]
+	return s.values[i.pos.value]
 }
 
 // Copy creates a copy of the slice.
@@ -232,6 +309,8 @@ func (s *Slice[T]) ElemAt(i *Index[T]) T {
 // Returns:
 //   - Slice[T]: The copy.
 func (s Slice[T]) Copy() Slice[T] {
+	s.poison.Check("s")
+
 	values := make([]T, len(s.values))
 	copy(values, s.values)
 
@@ -246,12 +325,17 @@ func (s Slice[T]) Copy() Slice[T] {
 //   - T: The deleted element.
 func (s *Slice[T]) DeleteFirst() T {
 	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
 
 	pkg.ThrowIf(len(s.values) == 0, fmt.Errorf("slice is empty"))
 
 	top := s.values[0]
 	s.values = s.values[1:]
 
+	if s.observers != nil {
+		s.observers.Notify(event.Removed[T]{Index: 0, Elem: top})
+	}
+
 	return top
 }
 
@@ -264,11 +348,17 @@ func (s *Slice[T]) DeleteFirst() T {
 // Panics with the message "index out of range" if the index is out of range.
 func (s *Slice[T]) SetAt(i *Index[T], elem T) {
 	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
 	pkg.Ensure(false, i)
 
 	pkg.ThrowIf(i.ref != s, fmt.Errorf("index refers to a different slice: %p", i.ref))
 
-	s.values[i.value] = elem
+	old := s.values[i.pos.value]
+	s.values[i.pos.value] = elem
+
+	if s.observers != nil {
+		s.observers.Notify(event.Set[T]{Index: i.pos.value, Old: old, New: elem})
+	}
 }
 
 // Has checks whether the slice contains the given element.
@@ -289,3 +379,143 @@ func (s *Slice[T]) Has(elem T) bool {
 
 	return false
 }
+
+// Freeze implements the pkg.Freezer interface.
+//
+// The elements that implement pkg.Freezer are frozen as well.
+func (s *Slice[T]) Freeze() {
+	pkg.Ensure(false, s)
+
+	if s.frozen {
+		return
+	}
+
+	s.frozen = true
+
+	for _, elem := range s.values {
+		pkg.Freeze(elem)
+	}
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (s Slice[T]) IsFrozen() bool {
+	return s.frozen
+}
+
+// Subscribe subscribes to the changes of the slice.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Appended, event.Removed[T],
+//     event.Set[T] and event.Reset[T] changes, synchronously after the
+//     mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (s *Slice[T]) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, s)
+
+	if s.observers == nil {
+		s.observers = &event.Observers{}
+	}
+
+	return s.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the slice into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (s *Slice[T]) Batch(fn func()) {
+	pkg.Ensure(false, s)
+
+	s.observers.Batch(fn)
+}
+
+// insert_at inserts an element at the given position and notifies it as
+// appended.
+//
+// Parameters:
+//   - i: The position. It must be in [0, len(s.values)].
+//   - elem: The element.
+func (s *Slice[T]) insert_at(i int, elem T) {
+	s.values = append(s.values, *new(T))
+	copy(s.values[i+1:], s.values[i:])
+	s.values[i] = elem
+
+	if s.observers != nil {
+		s.observers.Notify(event.Appended{Index: i})
+	}
+}
+
+// delete_at deletes the element at the given position and notifies it as
+// removed.
+//
+// Parameters:
+//   - i: The position. It must be in [0, len(s.values)).
+//
+// Returns:
+//   - T: The deleted element.
+func (s *Slice[T]) delete_at(i int) T {
+	elem := s.values[i]
+
+	copy(s.values[i:], s.values[i+1:])
+	s.values[len(s.values)-1] = *new(T)
+	s.values = s.values[:len(s.values)-1]
+
+	if s.observers != nil {
+		s.observers.Notify(event.Removed[T]{Index: i, Elem: elem})
+	}
+
+	return elem
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Appended, event.Removed[T], event.Set[T] or event.Reset[T] of the
+// slice. Reverting a reset does not clean the current elements; they are
+// handed back in the returned change.
+func (s *Slice[T]) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	switch c := c.(type) {
+	case event.Appended:
+		pkg.ThrowIf(c.Index < 0 || c.Index >= len(s.values), event.NewNotRevertible(c, s))
+
+		elem := s.delete_at(c.Index)
+
+		return event.Removed[T]{Index: c.Index, Elem: elem}
+	case event.Removed[T]:
+		pkg.ThrowIf(c.Index < 0 || c.Index > len(s.values), event.NewNotRevertible(c, s))
+
+		s.insert_at(c.Index, c.Elem)
+
+		return event.Appended{Index: c.Index}
+	case event.Set[T]:
+		pkg.ThrowIf(c.Index < 0 || c.Index >= len(s.values), event.NewNotRevertible(c, s))
+
+		s.values[c.Index] = c.Old
+
+		inverse := event.Set[T]{Index: c.Index, Old: c.New, New: c.Old}
+
+		if s.observers != nil {
+			s.observers.Notify(inverse)
+		}
+
+		return inverse
+	case event.Reset[T]:
+		inverse := event.Reset[T]{Old: s.values, New: c.Old}
+
+		s.values = append(make([]T, 0, len(c.Old)), c.Old...)
+
+		if s.observers != nil {
+			s.observers.Notify(inverse)
+		}
+
+		return inverse
+	default:
+		panic(event.NewNotRevertible(c, s))
+	}
+}
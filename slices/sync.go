@@ -0,0 +1,348 @@
+package slices
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// SyncSlice is a slice that is safe for concurrent use.
+//
+// By default, the readers share a read lock and the writers take the write
+// lock. In copy-on-write mode, the writers work on a copy of the slice that
+// is published once they are done, and the readers never lock: they see the
+// last published slice. This suits read-heavy workloads, as every write
+// deep-copies the slice, elements included, so that the published slices are
+// never mutated nor cleaned.
+//
+// Otherwise, the elements are shared, not copied; they must be safe for
+// concurrent use on their own if they are mutated.
+type SyncSlice[T pkg.Type] struct {
+	// mu serializes the writers and, unless in copy-on-write mode, excludes
+	// the readers while writing.
+	mu sync.RWMutex
+
+	// current is the current slice. In copy-on-write mode, a published slice
+	// is never mutated.
+	current atomic.Pointer[Slice[T]]
+
+	// cow is whether the slice is in copy-on-write mode. It is fixed at
+	// creation.
+	cow bool
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+}
+
+// String implements the fmt.Stringer interface.
+func (s *SyncSlice[T]) String() string {
+	s.poison.Check("s")
+
+	var str string
+
+	s.view(func(slice *Slice[T]) {
+		str = "Sync" + slice.String()
+	})
+
+	return str
+}
+
+// DeepCopy implements the pkg.Type interface.
+//
+// The copy is in the same mode as the slice.
+func (s *SyncSlice[T]) DeepCopy() pkg.Type {
+	if s == nil {
+		return nil
+	}
+
+	s.poison.Check("s")
+
+	var slice *Slice[T]
+
+	s.view(func(current *Slice[T]) {
+		slice = current.DeepCopy().(*Slice[T])
+	})
+
+	s_copy := &SyncSlice[T]{
+		cow: s.cow,
+	}
+
+	s_copy.current.Store(slice)
+
+	return s_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (s *SyncSlice[T]) Ensure() {
+	pkg.ThrowIf(s == nil, pkg.NewInvalidState("s", pkg.NewNilValue()))
+
+	s.poison.Check("s")
+}
+
+// Clean implements the pkg.Type interface.
+//
+// The slice must not be used concurrently with Clean.
+func (s *SyncSlice[T]) Clean() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.current.Swap(NewSlice[T]())
+	if old != nil {
+		pkg.CleanSlice(old.values)
+	}
+
+	s.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two sync slices are equal if their slices are equal at the time they are
+// read, regardless of their mode.
+func (s *SyncSlice[T]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, s)
+	pkg.Ensure(false, other)
+
+	other_val, ok := other.(*SyncSlice[T])
+	if !ok {
+		return false
+	}
+
+	// Read one slice at a time so that two concurrent calls with swapped
+	// arguments cannot deadlock.
+	current := &Slice[T]{
+		values: s.snapshot(),
+	}
+
+	other_current := &Slice[T]{
+		values: other_val.snapshot(),
+	}
+
+	return current.Equals(other_current)
+}
+
+// NewSyncSlice creates a new empty sync slice that locks its readers while
+// writing.
+//
+// Returns:
+//   - *SyncSlice[T]: The new slice. Never returns nil.
+func NewSyncSlice[T pkg.Type]() *SyncSlice[T] {
+	s := &SyncSlice[T]{}
+	s.current.Store(NewSlice[T]())
+
+	return s
+}
+
+// NewCopyOnWriteSlice creates a new empty sync slice in copy-on-write mode.
+//
+// Returns:
+//   - *SyncSlice[T]: The new slice. Never returns nil.
+func NewCopyOnWriteSlice[T pkg.Type]() *SyncSlice[T] {
+	s := &SyncSlice[T]{
+		cow: true,
+	}
+	s.current.Store(NewSlice[T]())
+
+	return s
+}
+
+// WithValue sets the values of the slice. The previous values are cleaned,
+// except in copy-on-write mode: the readers may still hold the published
+// slice, so its values are left to the garbage collector.
+//
+// Parameters:
+//   - slice: The slice.
+//
+// Returns:
+//   - *SyncSlice[T]: The slice. If the receiver is nil, a new sync slice that
+//     locks its readers is created. Never returns nil.
+func (s *SyncSlice[T]) WithValue(slice []T) *SyncSlice[T] {
+	if s == nil {
+		s = NewSyncSlice[T]()
+	}
+
+	s.poison.Check("s")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.current.Swap(&Slice[T]{
+		values: slice,
+	})
+
+	if !s.cow {
+		pkg.CleanSlice(old.values)
+	}
+
+	return s
+}
+
+// IsCopyOnWrite checks whether the slice is in copy-on-write mode.
+//
+// Returns:
+//   - bool: True if the slice is in copy-on-write mode, false otherwise.
+func (s *SyncSlice[T]) IsCopyOnWrite() bool {
+	pkg.Ensure(false, s)
+
+	return s.cow
+}
+
+// view calls fn with the current slice, which must not be mutated nor
+// retained.
+//
+// Parameters:
+//   - fn: The function to call.
+func (s *SyncSlice[T]) view(fn func(slice *Slice[T])) {
+	if !s.cow {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+
+	fn(s.current.Load())
+}
+
+// snapshot returns the current values. In copy-on-write mode, they are the
+// published ones and no copy is made.
+//
+// Returns:
+//   - []T: The values. They must not be mutated.
+func (s *SyncSlice[T]) snapshot() []T {
+	var values []T
+
+	s.view(func(slice *Slice[T]) {
+		if s.cow {
+			values = slice.values
+		} else {
+			values = append([]T(nil), slice.values...)
+		}
+	})
+
+	return values
+}
+
+// View calls fn with the current slice, excluding the writers until it
+// returns. In copy-on-write mode, the writers are not excluded and fn sees
+// the slice as it was when View was called.
+//
+// Parameters:
+//   - fn: The function to call. It must not mutate nor retain the slice.
+//
+// Panics if fn is nil.
+func (s *SyncSlice[T]) View(fn func(slice *Slice[T])) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(fn == nil, pkg.NewInvalidCall("fn", pkg.NewNilValue()))
+
+	s.view(fn)
+}
+
+// Update calls fn with the slice, excluding the other writers and, unless in
+// copy-on-write mode, the readers until it returns. This makes compound
+// operations atomic.
+//
+// In copy-on-write mode, fn receives a deep copy of the slice that is
+// published when fn returns; if fn panics, nothing is published. As the
+// elements are copied too, fn may mutate or clean them without affecting the
+// readers.
+//
+// Parameters:
+//   - fn: The function that mutates the slice. It must not retain the slice.
+//
+// Panics if fn is nil.
+func (s *SyncSlice[T]) Update(fn func(slice *Slice[T])) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(fn == nil, pkg.NewInvalidCall("fn", pkg.NewNilValue()))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.current.Load()
+
+	if !s.cow {
+		fn(current)
+		return
+	}
+
+	next := current.DeepCopy().(*Slice[T])
+
+	fn(next)
+
+	s.current.Store(next)
+}
+
+// Append adds an element to the slice.
+//
+// Parameters:
+//   - elem: The element to add.
+func (s *SyncSlice[T]) Append(elem T) {
+	s.Update(func(slice *Slice[T]) {
+		slice.Append(elem)
+	})
+}
+
+// IsEmpty checks whether the slice is empty.
+//
+// Returns:
+//   - bool: True if the slice is empty, false otherwise.
+func (s *SyncSlice[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Size returns the number of elements in the slice.
+//
+// Returns:
+//   - int: The number of elements in the slice.
+func (s *SyncSlice[T]) Size() int {
+	pkg.Ensure(false, s)
+
+	var size int
+
+	s.view(func(slice *Slice[T]) {
+		size = len(slice.values)
+	})
+
+	return size
+}
+
+// Snapshot returns a copy of the slice. The elements are shared.
+//
+// Returns:
+//   - *Slice[T]: The copy. Never returns nil.
+func (s *SyncSlice[T]) Snapshot() *Slice[T] {
+	pkg.Ensure(false, s)
+
+	values := s.snapshot()
+
+	if s.cow {
+		values = append([]T(nil), values...)
+	}
+
+	return &Slice[T]{
+		values: values,
+	}
+}
+
+// Each returns an iterator over the elements of the slice as they were when
+// Each was called. Concurrent writes are not seen by the iterator.
+//
+// Returns:
+//   - iter.Seq[T]: The iterator. Never returns nil.
+func (s *SyncSlice[T]) Each() iter.Seq[T] {
+	pkg.Ensure(false, s)
+
+	values := s.snapshot()
+
+	fn := func(yield func(T) bool) {
+		for _, elem := range values {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
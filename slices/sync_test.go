@@ -0,0 +1,136 @@
+package slices_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+	"github.com/PlayerR9/GoSD/types"
+)
+
+func TestSyncSlice(t *testing.T) {
+	for _, s := range []*slices.SyncSlice[*types.Int]{slices.NewSyncSlice[*types.Int](), slices.NewCopyOnWriteSlice[*types.Int]()} {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					s.Append(types.NewInt().WithValue(j))
+				}
+			}()
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					var count int
+
+					for range s.Each() {
+						count++
+					}
+
+					_ = s.String()
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if s.Size() != 800 {
+			t.Errorf("expected 800 elements, got %d", s.Size())
+		}
+	}
+}
+
+func TestSyncSliceUpdate(t *testing.T) {
+	s := slices.NewCopyOnWriteSlice[*types.Int]()
+	s.Append(types.NewInt().WithValue(1))
+
+	seq := s.Each()
+
+	s.Update(func(slice *slices.Slice[*types.Int]) {
+		slice.Append(types.NewInt().WithValue(2))
+		slice.Append(types.NewInt().WithValue(3))
+	})
+
+	var count int
+
+	for range seq {
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("expected the iterator to see 1 element, got %d", count)
+	}
+
+	if s.String() != "SyncSlice[1, 2, 3]" {
+		t.Errorf("expected SyncSlice[1, 2, 3], got %s", s)
+	}
+}
+
+func TestSyncSliceCopyOnWriteElements(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	s := slices.NewCopyOnWriteSlice[*types.Int]()
+	s.Append(types.NewInt().WithValue(1))
+
+	seq := s.Each()
+
+	s.Update(func(slice *slices.Slice[*types.Int]) {
+		for elem := range slice.Each() {
+			elem.Set(5)
+		}
+
+		slice.WithValue([]*types.Int{types.NewInt().WithValue(2)})
+	})
+
+	for elem := range seq {
+		if elem.Value() != 1 {
+			t.Errorf("expected the reader to keep 1, got %s", elem)
+		}
+	}
+}
+
+func TestSyncSliceCopyOnWriteWithValue(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	s := slices.NewCopyOnWriteSlice[*types.Int]().WithValue([]*types.Int{types.NewInt().WithValue(1)})
+
+	seq := s.Each()
+	s.WithValue(nil)
+
+	for elem := range seq {
+		if elem.Value() != 1 {
+			t.Errorf("expected the reader to keep 1, got %s", elem)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for j := 0; j < 200; j++ {
+			for elem := range s.Each() {
+				_ = elem.String()
+			}
+		}
+	}()
+
+	for j := 0; j < 200; j++ {
+		s.WithValue([]*types.Int{types.NewInt().WithValue(j)})
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,81 @@
+package slices
+
+import (
+	"errors"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Pair is the minimal interface of a pair of values, such as types.Pair. It
+// lets this package zip values without depending on the concrete pair type.
+type Pair[A, B pkg.Type] interface {
+	pkg.Type
+
+	// First returns the first value.
+	//
+	// Returns:
+	//   - A: The first value.
+	First() A
+
+	// Second returns the second value.
+	//
+	// Returns:
+	//   - B: The second value.
+	Second() B
+}
+
+// Zip pairs the elements of two slices by position. The pairs hold the
+// elements themselves, not copies.
+//
+// Parameters:
+//   - a: The first values.
+//   - b: The second values.
+//   - pair: The function that makes a pair, such as types.NewPair.
+//
+// Returns:
+//   - *Slice[P]: The pairs. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If the slices have different sizes.
+//
+// Panics if a, b or pair is nil.
+func Zip[A, B pkg.Type, P Pair[A, B]](a *Slice[A], b *Slice[B], pair func(A, B) P) *Slice[P] {
+	pkg.Ensure(false, a)
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(pair == nil, pkg.NewInvalidCall("pair", pkg.NewNilValue()))
+	pkg.ThrowIf(len(a.values) != len(b.values), pkg.NewIllegalArgument(errors.New("slices have different sizes")))
+
+	pairs := make([]P, 0, len(a.values))
+
+	for i, x := range a.values {
+		pairs = append(pairs, pair(x, b.values[i]))
+	}
+
+	return NewSlice[P]().WithValue(pairs)
+}
+
+// Unzip splits a slice of pairs into the slice of their first values and the
+// slice of their second ones. The slices hold the values themselves, not
+// copies.
+//
+// Parameters:
+//   - pairs: The pairs.
+//
+// Returns:
+//   - *Slice[A]: The first values. Never returns nil.
+//   - *Slice[B]: The second values. Never returns nil.
+//
+// Panics if pairs is nil.
+func Unzip[A, B pkg.Type, P Pair[A, B]](pairs *Slice[P]) (*Slice[A], *Slice[B]) {
+	pkg.Ensure(false, pairs)
+
+	firsts := make([]A, 0, len(pairs.values))
+	seconds := make([]B, 0, len(pairs.values))
+
+	for _, p := range pairs.values {
+		firsts = append(firsts, p.First())
+		seconds = append(seconds, p.Second())
+	}
+
+	return NewSlice[A]().WithValue(firsts), NewSlice[B]().WithValue(seconds)
+}
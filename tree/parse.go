@@ -0,0 +1,110 @@
+package tree
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Parse parses a tree from its String() form, that is, one node per line
+// where every node but the root is preceded by its indentation and a
+// "└── " or "├── " branch.
+//
+// Parameters:
+//   - text: The text to parse.
+//   - node: The function that parses a node from the rest of its line.
+//   - link: The function that adds a child to its parent.
+//
+// Returns:
+//   - *Tree[N]: The parsed tree. Nil if an error occurred.
+//   - error: An IllegalArgument error wrapping a *pkg.ErrParse if the text is
+//     not a valid tree.
+//
+// Panics if node or link is nil. Trees printed with a cycle warning cannot
+// be read back.
+func Parse[N interface {
+	Child() iter.Seq[N]
+	BackwardChild() iter.Seq[N]
+
+	TreeNoder
+}](text string, node pkg.ParseFunc[N], link func(parent, child N) error) (*Tree[N], error) {
+	pkg.ThrowIf(node == nil, pkg.NewInvalidCall("node", pkg.NewNilValue()))
+	pkg.ThrowIf(link == nil, pkg.NewInvalidCall("link", pkg.NewNilValue()))
+
+	s := pkg.NewScanner(text)
+
+	root, err := node(s)
+	if err != nil {
+		return nil, err
+	}
+
+	err = end_of_line(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// stack holds the last node seen at each depth.
+	stack := []N{root}
+
+	for !s.IsDone() {
+		var line, column int
+
+		depth := 0
+
+		for {
+			line, column = s.Position()
+
+			if s.Accept("└── ") || s.Accept("├── ") {
+				break
+			} else if !s.Accept("│   ") && !s.Accept("    ") {
+				return nil, s.Errorf("expected an indentation or a branch")
+			}
+
+			depth++
+		}
+
+		if depth == 0 {
+			return nil, s.ErrorAt(line, column, errors.New("a tree has only one root"))
+		} else if depth > len(stack) {
+			return nil, s.ErrorAt(line, column, fmt.Errorf("node at depth %d has no parent", depth))
+		}
+
+		if s.HasPrefix("... WARNING: Cycle detected!") {
+			return nil, s.Errorf("cycles cannot be parsed")
+		}
+
+		line, column = s.Position()
+
+		child, err := node(s)
+		if err != nil {
+			return nil, err
+		}
+
+		stack = stack[:depth]
+
+		err = link(stack[depth-1], child)
+		if err != nil {
+			return nil, s.ErrorAt(line, column, err)
+		}
+
+		stack = append(stack, child)
+
+		err = end_of_line(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewTree(root), nil
+}
+
+// end_of_line consumes the end of the current line.
+func end_of_line(s *pkg.Scanner) error {
+	if s.IsDone() || s.Accept("\n") {
+		return nil
+	}
+
+	return s.Errorf("expected the end of the line")
+}
@@ -0,0 +1,105 @@
+package tree
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+type node struct {
+	value    int
+	children []*node
+}
+
+func (n *node) String() string         { return strconv.Itoa(n.value) }
+func (n *node) Clean()                 {}
+func (n *node) Ensure()                {}
+func (n *node) IsLeaf() bool           { return len(n.children) == 0 }
+func (n *node) Child() iter.Seq[*node] { return slices.Values(n.children) }
+
+func (n *node) BackwardChild() iter.Seq[*node] {
+	return func(yield func(*node) bool) {
+		for _, c := range slices.Backward(n.children) {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+func (n *node) DeepCopy() pkg.Type {
+	c := &node{value: n.value}
+	for _, child := range n.children {
+		c.children = append(c.children, child.DeepCopy().(*node))
+	}
+
+	return c
+}
+
+func (n *node) Equals(other pkg.Type) bool {
+	o, ok := other.(*node)
+	if !ok || n.value != o.value || len(n.children) != len(o.children) {
+		return false
+	}
+
+	for i := range n.children {
+		if !n.children[i].Equals(o.children[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func scan_node(s *pkg.Scanner) (*node, error) {
+	line, column := s.Position()
+
+	value, err := strconv.Atoi(s.Until(func(r rune) bool { return r == '\n' }))
+	if err != nil {
+		return nil, s.ErrorAt(line, column, err)
+	}
+
+	return &node{value: value}, nil
+}
+
+func link_node(parent, child *node) error {
+	parent.children = append(parent.children, child)
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	root := &node{value: 1, children: []*node{
+		{value: 2, children: []*node{{value: 5}, {value: 6}}},
+		{value: 3},
+		{value: 4, children: []*node{{value: 7}}},
+	}}
+
+	want := NewTree(root)
+
+	got, err := Parse(want.String(), scan_node, link_node)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !want.Equals(got) {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	const text = "1\n    └── 2\n            └── 3\n"
+
+	_, err := Parse(text, scan_node, link_node)
+
+	perr := pkg.AsParseError(err)
+	if perr == nil {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+
+	if perr.Line != 3 || perr.Column != 13 {
+		t.Errorf("expected line 3, column 13, got line %d, column %d", perr.Line, perr.Column)
+	}
+}
@@ -0,0 +1,46 @@
+package tree
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// RegisterTree registers *Tree[N] under the name "Tree[{node}]", where {node}
+// is the name of N. The zero value is a tree whose root is the zero value of
+// N and the factory builds the root with the factory of N.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Panics if N is not registered in r.
+func RegisterTree[N interface {
+	Child() iter.Seq[N]
+	BackwardChild() iter.Seq[N]
+
+	TreeNoder
+}](r *pkg.Registry) *pkg.TypeInfo {
+	elem := pkg.InfoFor[N](r)
+
+	zero := func() *Tree[N] {
+		root, ok := elem.Zero().(N)
+		pkg.ThrowIf(!ok, pkg.NewInvalidState("root", errors.New("invalid type")))
+
+		return NewTree(root)
+	}
+
+	factory := func(value any) (*Tree[N], error) {
+		root, err := pkg.MakeElem[N](elem, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewTree(root), nil
+	}
+
+	return pkg.Register(r, "Tree["+elem.Name+"]", pkg.TreeKind, elem, zero, factory)
+}
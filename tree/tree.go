@@ -17,10 +17,15 @@ type Tree[T interface {
 }] struct {
 	// root is the root node of the tree.
 	root T
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
 }
 
 // String implements the fmt.Stringer interface.
 func (t Tree[T]) String() string {
+	t.poison.Check("t")
+
 	trav := PrintFn[T]()
 
 	info, err := ApplyDFS(&t, trav)
@@ -37,6 +42,8 @@ func (t *Tree[T]) DeepCopy() pkg.Type {
 		return nil
 	}
 
+	t.poison.Check("t")
+
 	root_copy := t.root.DeepCopy()
 
 	tmp, ok := root_copy.(T)
@@ -50,6 +57,7 @@ func (t *Tree[T]) DeepCopy() pkg.Type {
 // Ensure implements the pkg.Type interface.
 func (t *Tree[T]) Ensure() {
 	pkg.ThrowIf(t == nil, pkg.NewInvalidState("t", pkg.NewNilValue()))
+	t.poison.Check("t")
 
 	t.root.Ensure()
 }
@@ -60,7 +68,12 @@ func (t *Tree[T]) Clean() {
 		return
 	}
 
+	if t.poison.IsPoisoned() {
+		return
+	}
+
 	t.root.Clean()
+	t.poison.Mark()
 }
 
 // Equals implements the pkg.Type interface.
@@ -101,5 +114,7 @@ func NewTree[T interface {
 // Returns:
 //   - T: The root node of the tree.
 func (t Tree[T]) Root() T {
+	t.poison.Check("t")
+
 	return t.root
 }
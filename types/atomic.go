@@ -0,0 +1,354 @@
+package types
+
+import (
+	"iter"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// AtomicInt is an integer that is safe for concurrent use. Every method reads
+// or writes the value atomically, so Equals and DeepCopy see a consistent
+// value.
+type AtomicInt struct {
+	// value is the integer value.
+	value atomic.Int64
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+}
+
+// String implements the fmt.Stringer interface.
+func (a *AtomicInt) String() string {
+	a.poison.Check("a")
+
+	return strconv.Itoa(a.Load())
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (a *AtomicInt) DeepCopy() pkg.Type {
+	a.poison.Check("a")
+
+	a_copy := &AtomicInt{}
+	a_copy.value.Store(a.value.Load())
+
+	return a_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (a *AtomicInt) Ensure() {
+	pkg.ThrowIf(a == nil, pkg.NewInvalidState("a", pkg.NewNilValue()))
+
+	a.poison.Check("a")
+}
+
+// Clean implements the pkg.Type interface.
+func (a *AtomicInt) Clean() {
+	if a == nil {
+		return
+	}
+
+	a.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two atomic integers are equal if they have the same value when they are
+// read.
+func (a *AtomicInt) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, a)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *AtomicInt:
+		return a.value.Load() == other.value.Load()
+	default:
+		return false
+	}
+}
+
+// NewAtomicInt creates a new atomic integer set to 0.
+//
+// Returns:
+//   - *AtomicInt: The new atomic integer. Never returns nil.
+func NewAtomicInt() *AtomicInt {
+	return &AtomicInt{}
+}
+
+// WithValue sets the value of the atomic integer.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *AtomicInt: The atomic integer. If the receiver is nil, a new one is
+//     created. Never returns nil.
+func (a *AtomicInt) WithValue(value int) *AtomicInt {
+	if a == nil {
+		a = &AtomicInt{}
+	}
+
+	a.poison.Check("a")
+
+	a.value.Store(int64(value))
+
+	return a
+}
+
+// Reset sets the value back to 0.
+func (a *AtomicInt) Reset() {
+	if a == nil {
+		return
+	}
+
+	a.poison.Check("a")
+
+	a.value.Store(0)
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (a *AtomicInt) Recycle(key *pkg.RecycleKey) {
+	a.poison.Revive(key)
+	a.Reset()
+}
+
+// Load returns the value.
+//
+// Returns:
+//   - int: The value.
+func (a *AtomicInt) Load() int {
+	pkg.Ensure(false, a)
+
+	return int(a.value.Load())
+}
+
+// Store sets the value.
+//
+// Parameters:
+//   - value: The new value.
+func (a *AtomicInt) Store(value int) {
+	pkg.Ensure(false, a)
+
+	a.value.Store(int64(value))
+}
+
+// Add adds delta to the value.
+//
+// Parameters:
+//   - delta: The amount to add. It can be negative.
+//
+// Returns:
+//   - int: The new value.
+func (a *AtomicInt) Add(delta int) int {
+	pkg.Ensure(false, a)
+
+	return int(a.value.Add(int64(delta)))
+}
+
+// Swap sets the value and returns the previous one.
+//
+// Parameters:
+//   - value: The new value.
+//
+// Returns:
+//   - int: The previous value.
+func (a *AtomicInt) Swap(value int) int {
+	pkg.Ensure(false, a)
+
+	return int(a.value.Swap(int64(value)))
+}
+
+// CompareAndSwap sets the value to new if it is old.
+//
+// Parameters:
+//   - old: The expected value.
+//   - new: The new value.
+//
+// Returns:
+//   - bool: True if the value was swapped, false otherwise.
+func (a *AtomicInt) CompareAndSwap(old, new int) bool {
+	pkg.Ensure(false, a)
+
+	return a.value.CompareAndSwap(int64(old), int64(new))
+}
+
+// AtomicBool is a boolean that is safe for concurrent use. Every method reads
+// or writes the value atomically, so Equals and DeepCopy see a consistent
+// value.
+type AtomicBool struct {
+	// value is the boolean value.
+	value atomic.Bool
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The value is written as Bool does, "T" or "F".
+func (a *AtomicBool) String() string {
+	a.poison.Check("a")
+
+	if a.value.Load() {
+		return "T"
+	} else {
+		return "F"
+	}
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (a *AtomicBool) DeepCopy() pkg.Type {
+	a.poison.Check("a")
+
+	a_copy := &AtomicBool{}
+	a_copy.value.Store(a.value.Load())
+
+	return a_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (a *AtomicBool) Ensure() {
+	pkg.ThrowIf(a == nil, pkg.NewInvalidState("a", pkg.NewNilValue()))
+
+	a.poison.Check("a")
+}
+
+// Clean implements the pkg.Type interface.
+func (a *AtomicBool) Clean() {
+	if a == nil {
+		return
+	}
+
+	a.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two atomic booleans are equal if they have the same value when they are
+// read.
+func (a *AtomicBool) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, a)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *AtomicBool:
+		return a.value.Load() == other.value.Load()
+	default:
+		return false
+	}
+}
+
+// NewAtomicBool creates a new atomic boolean set to false.
+//
+// Returns:
+//   - *AtomicBool: The new atomic boolean. Never returns nil.
+func NewAtomicBool() *AtomicBool {
+	return &AtomicBool{}
+}
+
+// WithValue sets the value of the atomic boolean.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *AtomicBool: The atomic boolean. If the receiver is nil, a new one is
+//     created. Never returns nil.
+func (a *AtomicBool) WithValue(value bool) *AtomicBool {
+	if a == nil {
+		a = &AtomicBool{}
+	}
+
+	a.poison.Check("a")
+
+	a.value.Store(value)
+
+	return a
+}
+
+// Reset sets the value back to false.
+func (a *AtomicBool) Reset() {
+	if a == nil {
+		return
+	}
+
+	a.poison.Check("a")
+
+	a.value.Store(false)
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (a *AtomicBool) Recycle(key *pkg.RecycleKey) {
+	a.poison.Revive(key)
+	a.Reset()
+}
+
+// Load returns the value.
+//
+// Returns:
+//   - bool: The value.
+func (a *AtomicBool) Load() bool {
+	pkg.Ensure(false, a)
+
+	return a.value.Load()
+}
+
+// Store sets the value.
+//
+// Parameters:
+//   - value: The new value.
+func (a *AtomicBool) Store(value bool) {
+	pkg.Ensure(false, a)
+
+	a.value.Store(value)
+}
+
+// Swap sets the value and returns the previous one.
+//
+// Parameters:
+//   - value: The new value.
+//
+// Returns:
+//   - bool: The previous value.
+func (a *AtomicBool) Swap(value bool) bool {
+	pkg.Ensure(false, a)
+
+	return a.value.Swap(value)
+}
+
+// CompareAndSwap sets the value to new if it is old.
+//
+// Parameters:
+//   - old: The expected value.
+//   - new: The new value.
+//
+// Returns:
+//   - bool: True if the value was swapped, false otherwise.
+func (a *AtomicBool) CompareAndSwap(old, new bool) bool {
+	pkg.Ensure(false, a)
+
+	return a.value.CompareAndSwap(old, new)
+}
+
+// Each creates an iterator that iterates over the atomic boolean.
+//
+// Returns:
+//   - iter.Seq[*AtomicBool]: The iterator. Never returns nil.
+//
+// The iterator always returns until a break, return statement is reached, or the value
+// is set to false, possibly by another goroutine. The value is read again before each
+// iteration.
+func (a *AtomicBool) Each() iter.Seq[*AtomicBool] {
+	pkg.Ensure(false, a)
+
+	fn := func(yield func(*AtomicBool) bool) {
+		for a.value.Load() {
+			if !yield(a) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
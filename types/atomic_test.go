@@ -0,0 +1,77 @@
+package types
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomicInt(t *testing.T) {
+	a := NewAtomicInt()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				a.Add(1)
+			}
+
+			_ = a.DeepCopy()
+		}()
+	}
+
+	wg.Wait()
+
+	if a.Load() != 8000 {
+		t.Fatalf("expected 8000, got %d", a.Load())
+	}
+
+	if a.CompareAndSwap(0, 1) {
+		t.Errorf("expected CompareAndSwap to fail")
+	}
+
+	if old := a.Swap(3); old != 8000 || !a.Equals(NewAtomicInt().WithValue(3)) {
+		t.Errorf("expected Swap to return 8000 and set 3, got %d and %s", old, a)
+	}
+}
+
+func TestAtomicBoolEach(t *testing.T) {
+	b := NewAtomicBool().WithValue(true)
+
+	var count AtomicInt
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range b.Each() {
+			count.Add(1)
+		}
+	}()
+
+	for count.Load() < 10 {
+	}
+
+	b.Store(false)
+	<-done
+
+	if b.Load() {
+		t.Errorf("expected false")
+	}
+}
+
+func TestAtomicBoolString(t *testing.T) {
+	for _, value := range []bool{true, false} {
+		a := NewAtomicBool().WithValue(value)
+		b := NewBool().WithValue(value)
+
+		if a.String() != b.String() {
+			t.Errorf("expected %q, got %q", b.String(), a.String())
+		}
+	}
+}
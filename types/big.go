@@ -0,0 +1,673 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// BigInt is an arbitrary-precision integer. Its arithmetic methods update the
+// integer in place. It never shares its internal big.Int: the values passed
+// in and out are copied.
+type BigInt struct {
+	// value is the integer value.
+	value big.Int
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The integer is written in base 10, with a leading '-' if negative.
+func (b *BigInt) String() string {
+	b.poison.Check("b")
+
+	return b.value.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (b *BigInt) DeepCopy() pkg.Type {
+	b.poison.Check("b")
+
+	b_copy := &BigInt{}
+	b_copy.value.Set(&b.value)
+
+	return b_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (b *BigInt) Ensure() {
+	pkg.ThrowIf(b == nil, pkg.NewInvalidState("b", pkg.NewNilValue()))
+
+	b.poison.Check("b")
+}
+
+// Clean implements the pkg.Type interface.
+func (b *BigInt) Clean() {
+	if b == nil {
+		return
+	}
+
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	b.value.SetInt64(0)
+
+	b.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two big integers are equal if they have the same value.
+func (b *BigInt) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, b)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *BigInt:
+		return b.value.Cmp(&other.value) == 0
+	default:
+		return false
+	}
+}
+
+// NewBigInt creates a new big integer.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *BigInt: The new big integer. Never returns nil.
+func NewBigInt(value int64) *BigInt {
+	b := &BigInt{}
+	b.value.SetInt64(value)
+
+	return b
+}
+
+// BigIntOf creates a new big integer from a copy of a big.Int.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *BigInt: The new big integer. Never returns nil.
+//
+// Panics if value is nil.
+func BigIntOf(value *big.Int) *BigInt {
+	pkg.ThrowIf(value == nil, pkg.NewInvalidCall("value", pkg.NewNilValue()))
+
+	b := &BigInt{}
+	b.value.Set(value)
+
+	return b
+}
+
+// ParseBigInt parses a big integer written in base 10, as done by String.
+//
+// Parameters:
+//   - str: The string to parse.
+//
+// Returns:
+//   - *BigInt: The big integer. Nil if an error occurred.
+//   - error: An error if the string is not an integer.
+//
+// Errors:
+//   - *IllegalArgument: If the string is not an integer.
+func ParseBigInt(str string) (*BigInt, error) {
+	b := &BigInt{}
+
+	_, ok := b.value.SetString(str, 10)
+	if !ok {
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("%q is not an integer", str))
+	}
+
+	return b, nil
+}
+
+// Big returns a copy of the value.
+//
+// Returns:
+//   - *big.Int: The copy. Never returns nil.
+func (b *BigInt) Big() *big.Int {
+	pkg.Ensure(false, b)
+
+	return new(big.Int).Set(&b.value)
+}
+
+// Int64 returns the value as an int64.
+//
+// Returns:
+//   - int64: The value. Undefined if an error occurred.
+//   - error: An error if the value does not fit in an int64.
+//
+// Errors:
+//   - *LossyConversion: If the value does not fit in an int64.
+func (b *BigInt) Int64() (int64, error) {
+	pkg.Ensure(false, b)
+
+	if !b.value.IsInt64() {
+		return b.value.Int64(), pkg.NewLossyConversion(fmt.Errorf("%s cannot be represented as int64", &b.value))
+	}
+
+	return b.value.Int64(), nil
+}
+
+// Sign returns the sign of the value.
+//
+// Returns:
+//   - int: -1 if the value is negative, 0 if it is zero and 1 otherwise.
+func (b *BigInt) Sign() int {
+	pkg.Ensure(false, b)
+
+	return b.value.Sign()
+}
+
+// Compare compares the big integer with another one. It can be used to sort
+// big integers with slices.SortFunc.
+//
+// Parameters:
+//   - other: The other big integer.
+//
+// Returns:
+//   - int: -1 if b < other, 0 if b == other and 1 if b > other.
+//
+// Panics if other is nil.
+func (b *BigInt) Compare(other *BigInt) int {
+	pkg.Ensure(false, b)
+	pkg.Ensure(false, other)
+
+	return b.value.Cmp(&other.value)
+}
+
+// Set sets the value from a copy of another big integer.
+//
+// Parameters:
+//   - other: The other big integer.
+//
+// Returns:
+//   - *BigInt: The big integer. Never returns nil.
+func (b *BigInt) Set(other *BigInt) *BigInt {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+	pkg.Ensure(false, other)
+
+	b.value.Set(&other.value)
+
+	return b
+}
+
+// Add adds other to the big integer.
+//
+// Parameters:
+//   - other: The value to add.
+//
+// Returns:
+//   - *BigInt: The big integer. Never returns nil.
+func (b *BigInt) Add(other *BigInt) *BigInt {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+	pkg.Ensure(false, other)
+
+	b.value.Add(&b.value, &other.value)
+
+	return b
+}
+
+// Sub subtracts other from the big integer.
+//
+// Parameters:
+//   - other: The value to subtract.
+//
+// Returns:
+//   - *BigInt: The big integer. Never returns nil.
+func (b *BigInt) Sub(other *BigInt) *BigInt {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+	pkg.Ensure(false, other)
+
+	b.value.Sub(&b.value, &other.value)
+
+	return b
+}
+
+// Mul multiplies the big integer by other.
+//
+// Parameters:
+//   - other: The value to multiply by.
+//
+// Returns:
+//   - *BigInt: The big integer. Never returns nil.
+func (b *BigInt) Mul(other *BigInt) *BigInt {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+	pkg.Ensure(false, other)
+
+	b.value.Mul(&b.value, &other.value)
+
+	return b
+}
+
+// Quo divides the big integer by other, truncating toward zero like the /
+// operator.
+//
+// Parameters:
+//   - other: The value to divide by.
+//
+// Returns:
+//   - *BigInt: The big integer. Never returns nil.
+//
+// Throws:
+//   - *DivisionByZero: If other is 0.
+func (b *BigInt) Quo(other *BigInt) *BigInt {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+	pkg.Ensure(false, other)
+	pkg.ThrowIf(other.value.Sign() == 0, pkg.NewDivisionByZero())
+
+	b.value.Quo(&b.value, &other.value)
+
+	return b
+}
+
+// Rem sets the big integer to the remainder of its division by other. The
+// result has the sign of the big integer, like the % operator.
+//
+// Parameters:
+//   - other: The divisor.
+//
+// Returns:
+//   - *BigInt: The big integer. Never returns nil.
+//
+// Throws:
+//   - *DivisionByZero: If other is 0.
+func (b *BigInt) Rem(other *BigInt) *BigInt {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+	pkg.Ensure(false, other)
+	pkg.ThrowIf(other.value.Sign() == 0, pkg.NewDivisionByZero())
+
+	b.value.Rem(&b.value, &other.value)
+
+	return b
+}
+
+// Neg negates the big integer.
+//
+// Returns:
+//   - *BigInt: The big integer. Never returns nil.
+func (b *BigInt) Neg() *BigInt {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	b.value.Neg(&b.value)
+
+	return b
+}
+
+// Abs sets the big integer to its absolute value.
+//
+// Returns:
+//   - *BigInt: The big integer. Never returns nil.
+func (b *BigInt) Abs() *BigInt {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	b.value.Abs(&b.value)
+
+	return b
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (b *BigInt) Freeze() {
+	pkg.Ensure(false, b)
+
+	b.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (b *BigInt) IsFrozen() bool {
+	return b.frozen
+}
+
+// Rat is an arbitrary-precision rational number, always kept in lowest
+// terms. Its arithmetic methods update the number in place. It never shares
+// its internal big.Rat: the values passed in and out are copied.
+type Rat struct {
+	// value is the rational value.
+	value big.Rat
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The number is written as "a/b" in lowest terms, or as "a" if it is an
+// integer.
+func (r *Rat) String() string {
+	r.poison.Check("r")
+
+	return r.value.RatString()
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (r *Rat) DeepCopy() pkg.Type {
+	r.poison.Check("r")
+
+	r_copy := &Rat{}
+	r_copy.value.Set(&r.value)
+
+	return r_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (r *Rat) Ensure() {
+	pkg.ThrowIf(r == nil, pkg.NewInvalidState("r", pkg.NewNilValue()))
+
+	r.poison.Check("r")
+}
+
+// Clean implements the pkg.Type interface.
+func (r *Rat) Clean() {
+	if r == nil {
+		return
+	}
+
+	pkg.ThrowIf(r.frozen, pkg.NewFrozen("r"))
+
+	r.value.SetInt64(0)
+
+	r.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two rationals are equal if they have the same value.
+func (r *Rat) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, r)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Rat:
+		return r.value.Cmp(&other.value) == 0
+	default:
+		return false
+	}
+}
+
+// NewRat creates a new rational number a/b.
+//
+// Parameters:
+//   - a: The numerator.
+//   - b: The denominator.
+//
+// Returns:
+//   - *Rat: The new rational number. Never returns nil.
+//
+// Throws:
+//   - *DivisionByZero: If b is 0.
+func NewRat(a, b int64) *Rat {
+	pkg.ThrowIf(b == 0, pkg.NewDivisionByZero())
+
+	r := &Rat{}
+	r.value.SetFrac64(a, b)
+
+	return r
+}
+
+// RatOf creates a new rational number from a copy of a big.Rat.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *Rat: The new rational number. Never returns nil.
+//
+// Panics if value is nil.
+func RatOf(value *big.Rat) *Rat {
+	pkg.ThrowIf(value == nil, pkg.NewInvalidCall("value", pkg.NewNilValue()))
+
+	r := &Rat{}
+	r.value.Set(value)
+
+	return r
+}
+
+// ParseRat parses a rational number written as "a/b", as done by String, or
+// as a decimal number such as "-1.25" or "3e-2".
+//
+// Parameters:
+//   - str: The string to parse.
+//
+// Returns:
+//   - *Rat: The rational number. Nil if an error occurred.
+//   - error: An error if the string is not a rational number.
+//
+// Errors:
+//   - *IllegalArgument: If the string is not a rational number.
+//   - *DivisionByZero: If the denominator is 0.
+func ParseRat(str string) (*Rat, error) {
+	_, denom, found := strings.Cut(str, "/")
+	if found {
+		var d big.Int
+
+		_, ok := d.SetString(denom, 10)
+		if ok && d.Sign() == 0 {
+			return nil, pkg.NewDivisionByZero()
+		}
+	}
+
+	r := &Rat{}
+
+	_, ok := r.value.SetString(str)
+	if !ok {
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("%q is not a rational number", str))
+	}
+
+	return r, nil
+}
+
+// Big returns a copy of the value.
+//
+// Returns:
+//   - *big.Rat: The copy. Never returns nil.
+func (r *Rat) Big() *big.Rat {
+	pkg.Ensure(false, r)
+
+	return new(big.Rat).Set(&r.value)
+}
+
+// Num returns a copy of the numerator.
+//
+// Returns:
+//   - *BigInt: The numerator. It has the sign of the number. Never returns
+//     nil.
+func (r *Rat) Num() *BigInt {
+	pkg.Ensure(false, r)
+
+	return BigIntOf(r.value.Num())
+}
+
+// Denom returns a copy of the denominator.
+//
+// Returns:
+//   - *BigInt: The denominator. It is always positive. Never returns nil.
+func (r *Rat) Denom() *BigInt {
+	pkg.Ensure(false, r)
+
+	return BigIntOf(r.value.Denom())
+}
+
+// Float64 returns the nearest float64 to the value.
+//
+// Returns:
+//   - float64: The nearest float64.
+//   - bool: True if the float64 is exactly the value, false otherwise.
+func (r *Rat) Float64() (float64, bool) {
+	pkg.Ensure(false, r)
+
+	return r.value.Float64()
+}
+
+// Sign returns the sign of the value.
+//
+// Returns:
+//   - int: -1 if the value is negative, 0 if it is zero and 1 otherwise.
+func (r *Rat) Sign() int {
+	pkg.Ensure(false, r)
+
+	return r.value.Sign()
+}
+
+// Compare compares the rational number with another one. It can be used to
+// sort rational numbers with slices.SortFunc.
+//
+// Parameters:
+//   - other: The other rational number.
+//
+// Returns:
+//   - int: -1 if r < other, 0 if r == other and 1 if r > other.
+//
+// Panics if other is nil.
+func (r *Rat) Compare(other *Rat) int {
+	pkg.Ensure(false, r)
+	pkg.Ensure(false, other)
+
+	return r.value.Cmp(&other.value)
+}
+
+// Set sets the value from a copy of another rational number.
+//
+// Parameters:
+//   - other: The other rational number.
+//
+// Returns:
+//   - *Rat: The rational number. Never returns nil.
+func (r *Rat) Set(other *Rat) *Rat {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(r.frozen, pkg.NewFrozen("r"))
+	pkg.Ensure(false, other)
+
+	r.value.Set(&other.value)
+
+	return r
+}
+
+// Add adds other to the rational number.
+//
+// Parameters:
+//   - other: The value to add.
+//
+// Returns:
+//   - *Rat: The rational number. Never returns nil.
+func (r *Rat) Add(other *Rat) *Rat {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(r.frozen, pkg.NewFrozen("r"))
+	pkg.Ensure(false, other)
+
+	r.value.Add(&r.value, &other.value)
+
+	return r
+}
+
+// Sub subtracts other from the rational number.
+//
+// Parameters:
+//   - other: The value to subtract.
+//
+// Returns:
+//   - *Rat: The rational number. Never returns nil.
+func (r *Rat) Sub(other *Rat) *Rat {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(r.frozen, pkg.NewFrozen("r"))
+	pkg.Ensure(false, other)
+
+	r.value.Sub(&r.value, &other.value)
+
+	return r
+}
+
+// Mul multiplies the rational number by other.
+//
+// Parameters:
+//   - other: The value to multiply by.
+//
+// Returns:
+//   - *Rat: The rational number. Never returns nil.
+func (r *Rat) Mul(other *Rat) *Rat {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(r.frozen, pkg.NewFrozen("r"))
+	pkg.Ensure(false, other)
+
+	r.value.Mul(&r.value, &other.value)
+
+	return r
+}
+
+// Quo divides the rational number by other.
+//
+// Parameters:
+//   - other: The value to divide by.
+//
+// Returns:
+//   - *Rat: The rational number. Never returns nil.
+//
+// Throws:
+//   - *DivisionByZero: If other is 0.
+func (r *Rat) Quo(other *Rat) *Rat {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(r.frozen, pkg.NewFrozen("r"))
+	pkg.Ensure(false, other)
+	pkg.ThrowIf(other.value.Sign() == 0, pkg.NewDivisionByZero())
+
+	r.value.Quo(&r.value, &other.value)
+
+	return r
+}
+
+// Neg negates the rational number.
+//
+// Returns:
+//   - *Rat: The rational number. Never returns nil.
+func (r *Rat) Neg() *Rat {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(r.frozen, pkg.NewFrozen("r"))
+
+	r.value.Neg(&r.value)
+
+	return r
+}
+
+// Abs sets the rational number to its absolute value.
+//
+// Returns:
+//   - *Rat: The rational number. Never returns nil.
+func (r *Rat) Abs() *Rat {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(r.frozen, pkg.NewFrozen("r"))
+
+	r.value.Abs(&r.value)
+
+	return r
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (r *Rat) Freeze() {
+	pkg.Ensure(false, r)
+
+	r.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (r *Rat) IsFrozen() bool {
+	return r.frozen
+}
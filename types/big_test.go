@@ -0,0 +1,118 @@
+package types
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+func TestBigInt(t *testing.T) {
+	b, err := ParseBigInt("-123456789012345678901234567890")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b_copy := b.DeepCopy().(*BigInt)
+	b.Mul(NewBigInt(2))
+
+	if b_copy.String() != "-123456789012345678901234567890" {
+		t.Errorf("expected the copy not to alias the original, got %s", b_copy)
+	}
+
+	if !b.Equals(NewBigInt(0).Sub(b_copy).Sub(b_copy).Neg()) {
+		t.Errorf("expected %s to equal 2 * %s", b, b_copy)
+	}
+
+	if _, err := b.Int64(); err == nil {
+		t.Errorf("expected a lossy conversion")
+	}
+
+	if code := code_of(func() { b.Quo(NewBigInt(0)) }); code != pkg.DivisionByZero {
+		t.Errorf("expected DivisionByZero, got %v", code)
+	}
+}
+
+func TestRat(t *testing.T) {
+	r, err := ParseRat("6/8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.String() != "3/4" {
+		t.Errorf("expected 3/4, got %s", r)
+	}
+
+	if r.Add(NewRat(1, 4)).String() != "1" {
+		t.Errorf("expected 1, got %s", r)
+	}
+
+	if _, err := ParseRat("1/0"); err == nil {
+		t.Errorf("expected an error for 1/0")
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		mode RoundingMode
+		in   string
+		want string
+	}{
+		{RoundHalfEven, "2.345", "2.34"},
+		{RoundHalfEven, "2.355", "2.36"},
+		{RoundHalfUp, "-2.345", "-2.35"},
+		{RoundHalfDown, "2.345", "2.34"},
+		{RoundUp, "2.341", "2.35"},
+		{RoundDown, "-2.349", "-2.34"},
+		{RoundCeiling, "-2.349", "-2.34"},
+		{RoundFloor, "-2.341", "-2.35"},
+		{RoundHalfEven, "0.005", "0.00"},
+		{RoundHalfEven, "-0.5", "-0.50"},
+	}
+
+	for _, test := range tests {
+		exact, err := ParseDecimal(test.in, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		d := NewDecimal(2).WithRounding(test.mode).Set(exact)
+		if d.String() != test.want {
+			t.Errorf("%v(%s): expected %s, got %s", test.mode, test.in, test.want, d)
+		}
+	}
+
+	price, _ := ParseDecimal("19.99", 2)
+	qty, _ := ParseDecimal("3", 0)
+
+	total := price.DeepCopy().(*Decimal).Mul(qty)
+	if total.String() != "59.97" || price.String() != "19.99" {
+		t.Errorf("expected 59.97 and 19.99, got %s and %s", total, price)
+	}
+
+	third := NewDecimal(4).Set(qty).Quo(NewDecimal(0).Set(qty).Mul(qty))
+	if third.String() != "0.3333" {
+		t.Errorf("expected 0.3333, got %s", third)
+	}
+
+	values := []*Decimal{total, price, third}
+	slices.SortFunc(values, (*Decimal).Compare)
+
+	if values[0] != third || values[2] != total {
+		t.Errorf("unexpected order: %v", values)
+	}
+
+	a, _ := ParseDecimal("1.5", 1)
+	b, _ := ParseDecimal("1.50", 2)
+
+	// Equal decimals print the same, so the scale is part of equality.
+	if a.Equals(b) || a.Compare(b) != 0 {
+		t.Errorf("expected %s and %s to compare equal without being equal", a, b)
+	}
+
+	c, _ := ParseDecimal("1.50", 2)
+
+	if !b.Equals(c) || b.String() != c.String() {
+		t.Errorf("expected %s to equal %s", b, c)
+	}
+}
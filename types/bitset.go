@@ -0,0 +1,539 @@
+package types
+
+import (
+	"errors"
+	"iter"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// BitSet is a set of non-negative integers stored as a bit array, one bit per
+// integer up to the largest element. Set operations work a word at a time.
+type BitSet struct {
+	// words are the bits of the set; bit i%64 of words[i/64] is set if i is
+	// in the set. Words past the last one with a set bit may be zero.
+	words []uint64
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BitSet) String() string {
+	b.poison.Check("b")
+
+	var builder strings.Builder
+
+	builder.WriteString("BitSet[")
+
+	var values []string
+	for i := range b.Each() {
+		values = append(values, strconv.Itoa(i))
+	}
+	builder.WriteString(strings.Join(values, ", "))
+
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (b *BitSet) DeepCopy() pkg.Type {
+	if b == nil {
+		return nil
+	}
+
+	b.poison.Check("b")
+
+	return &BitSet{
+		words: append([]uint64(nil), b.words...),
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (b *BitSet) Ensure() {
+	pkg.ThrowIf(b == nil, pkg.NewInvalidState("b", pkg.NewNilValue()))
+
+	b.poison.Check("b")
+}
+
+// Clean implements the pkg.Type interface.
+func (b *BitSet) Clean() {
+	if b == nil {
+		return
+	}
+
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	b.words = nil
+
+	b.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two bit sets are equal if they have the same elements, regardless of their
+// capacity.
+func (b *BitSet) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, b)
+	pkg.Ensure(false, other)
+
+	other_val, ok := other.(*BitSet)
+	if !ok {
+		return false
+	}
+
+	short, long := b.words, other_val.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewBitSet creates a new bit set.
+//
+// Parameters:
+//   - values: The initial elements.
+//
+// Returns:
+//   - *BitSet: The new bit set. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If a value is negative.
+func NewBitSet(values ...int) *BitSet {
+	b := &BitSet{}
+
+	for _, i := range values {
+		b.Add(i)
+	}
+
+	return b
+}
+
+// BitSetOf creates a bit set with the values of a set of integers.
+//
+// Parameters:
+//   - s: The set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *BitSet: The new bit set. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If a value is negative.
+func BitSetOf(s *Set[*Int]) *BitSet {
+	b := &BitSet{}
+
+	if s == nil {
+		return b
+	}
+
+	pkg.Ensure(false, s)
+
+	for _, elem := range s.values {
+		b.Add(elem.Value())
+	}
+
+	return b
+}
+
+// ToSet returns the elements of the bit set as a set of integers, in
+// increasing order.
+//
+// Returns:
+//   - *Set[*Int]: The new set. Never returns nil.
+func (b *BitSet) ToSet() *Set[*Int] {
+	pkg.Ensure(false, b)
+
+	s := NewSet[*Int]()
+
+	for i := range b.Each() {
+		s.values = append(s.values, NewInt().WithValue(i))
+	}
+
+	return s
+}
+
+// check_index panics if an element is negative.
+//
+// Parameters:
+//   - i: The element.
+func check_index(i int) {
+	pkg.ThrowIf(i < 0, pkg.NewIllegalArgument(errors.New("bit set elements must not be negative")))
+}
+
+// Add adds an element to the set, growing it if needed.
+//
+// Parameters:
+//   - i: The element.
+//
+// Returns:
+//   - bool: True if the element was not in the set, false otherwise.
+//
+// Throws:
+//   - *IllegalArgument: If i is negative.
+func (b *BitSet) Add(i int) bool {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+	check_index(i)
+
+	w := i / 64
+
+	if w >= len(b.words) {
+		b.words = append(b.words, make([]uint64, w+1-len(b.words))...)
+	}
+
+	mask := uint64(1) << (i % 64)
+	if b.words[w]&mask != 0 {
+		return false
+	}
+
+	b.words[w] |= mask
+
+	return true
+}
+
+// Remove removes an element from the set.
+//
+// Parameters:
+//   - i: The element.
+//
+// Returns:
+//   - bool: True if the element was in the set, false otherwise.
+//
+// Throws:
+//   - *IllegalArgument: If i is negative.
+func (b *BitSet) Remove(i int) bool {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	if !b.Has(i) {
+		return false
+	}
+
+	b.words[i/64] &^= uint64(1) << (i % 64)
+
+	return true
+}
+
+// Has checks whether an element is in the set.
+//
+// Parameters:
+//   - i: The element.
+//
+// Returns:
+//   - bool: True if the element is in the set, false otherwise.
+//
+// Throws:
+//   - *IllegalArgument: If i is negative.
+func (b *BitSet) Has(i int) bool {
+	pkg.Ensure(false, b)
+	check_index(i)
+
+	w := i / 64
+
+	return w < len(b.words) && b.words[w]&(uint64(1)<<(i%64)) != 0
+}
+
+// Count returns the number of elements in the set.
+//
+// Returns:
+//   - int: The number of elements.
+func (b *BitSet) Count() int {
+	b.poison.Check("b")
+
+	var count int
+
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+
+	return count
+}
+
+// IsEmpty checks whether the set is empty.
+//
+// Returns:
+//   - bool: True if the set is empty, false otherwise.
+func (b *BitSet) IsEmpty() bool {
+	b.poison.Check("b")
+
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NextSet returns the smallest element that is greater than or equal to i.
+//
+// Parameters:
+//   - i: The lower bound. Negative values are treated as 0.
+//
+// Returns:
+//   - int: The element, or -1 if there is none.
+func (b *BitSet) NextSet(i int) int {
+	pkg.Ensure(false, b)
+
+	i = max(i, 0)
+	w := i / 64
+
+	if w >= len(b.words) {
+		return -1
+	}
+
+	word := b.words[w] >> (i % 64)
+	if word != 0 {
+		return i + bits.TrailingZeros64(word)
+	}
+
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return w*64 + bits.TrailingZeros64(b.words[w])
+		}
+	}
+
+	return -1
+}
+
+// NextClear returns the smallest non-negative integer that is greater than or
+// equal to i and not in the set.
+//
+// Parameters:
+//   - i: The lower bound. Negative values are treated as 0.
+//
+// Returns:
+//   - int: The integer. Always found, as the set is finite.
+func (b *BitSet) NextClear(i int) int {
+	pkg.Ensure(false, b)
+
+	i = max(i, 0)
+	w := i / 64
+
+	if w >= len(b.words) {
+		return i
+	}
+
+	word := ^b.words[w] >> (i % 64)
+	if word != 0 {
+		return i + bits.TrailingZeros64(word)
+	}
+
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != ^uint64(0) {
+			return w*64 + bits.TrailingZeros64(^b.words[w])
+		}
+	}
+
+	return len(b.words) * 64
+}
+
+// Rank returns the number of elements that are less than i.
+//
+// Parameters:
+//   - i: The bound.
+//
+// Returns:
+//   - int: The number of elements below i.
+func (b *BitSet) Rank(i int) int {
+	pkg.Ensure(false, b)
+
+	if i <= 0 {
+		return 0
+	}
+
+	w := i / 64
+
+	var count int
+
+	for _, word := range b.words[:min(w, len(b.words))] {
+		count += bits.OnesCount64(word)
+	}
+
+	if w < len(b.words) {
+		count += bits.OnesCount64(b.words[w] & (uint64(1)<<(i%64) - 1))
+	}
+
+	return count
+}
+
+// Select returns the element of a given rank, that is, the k-th smallest
+// element counting from 0. It is the inverse of Rank.
+//
+// Parameters:
+//   - k: The rank.
+//
+// Returns:
+//   - int: The element, or -1 if k is negative or not less than Count.
+func (b *BitSet) Select(k int) int {
+	pkg.Ensure(false, b)
+
+	if k < 0 {
+		return -1
+	}
+
+	for w, word := range b.words {
+		count := bits.OnesCount64(word)
+
+		if k >= count {
+			k -= count
+			continue
+		}
+
+		for range k {
+			word &= word - 1
+		}
+
+		return w*64 + bits.TrailingZeros64(word)
+	}
+
+	return -1
+}
+
+// Union adds the elements of other.
+//
+// Parameters:
+//   - other: The other bit set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *BitSet: The receiver. Never returns nil.
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	if other == nil {
+		return b
+	}
+
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+
+	return b
+}
+
+// Intersection removes the elements that are not in other.
+//
+// Parameters:
+//   - other: The other bit set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *BitSet: The receiver. Never returns nil.
+func (b *BitSet) Intersection(other *BitSet) *BitSet {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	var others []uint64
+	if other != nil {
+		others = other.words
+	}
+
+	for i := range b.words {
+		if i < len(others) {
+			b.words[i] &= others[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+
+	return b
+}
+
+// Difference removes the elements that are in other.
+//
+// Parameters:
+//   - other: The other bit set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *BitSet: The receiver. Never returns nil.
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	if other == nil {
+		return b
+	}
+
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+
+	return b
+}
+
+// Reset removes all elements from the set.
+func (b *BitSet) Reset() {
+	if b == nil {
+		return
+	}
+
+	b.poison.Check("b")
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	clear(b.words)
+	b.words = b.words[:0]
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (b *BitSet) Recycle(key *pkg.RecycleKey) {
+	b.poison.Revive(key)
+	b.Reset()
+}
+
+// Each returns an iterator over the elements of the set, in increasing order.
+//
+// Returns:
+//   - iter.Seq[int]: The iterator. Never returns nil.
+func (b *BitSet) Each() iter.Seq[int] {
+	b.poison.Check("b")
+
+	fn := func(yield func(int) bool) {
+		for w, word := range b.words {
+			for word != 0 {
+				if !yield(w*64 + bits.TrailingZeros64(word)) {
+					return
+				}
+
+				word &= word - 1
+			}
+		}
+	}
+
+	return fn
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (b *BitSet) Freeze() {
+	pkg.Ensure(false, b)
+
+	b.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (b *BitSet) IsFrozen() bool {
+	return b.frozen
+}
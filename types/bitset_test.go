@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	b := NewBitSet(3, 64, 65, 200)
+
+	if b.Count() != 4 || !b.Has(64) || b.Has(63) || b.Has(1000) {
+		t.Fatalf("unexpected bit set: %s", b)
+	}
+
+	tests := []struct {
+		name      string
+		got, want int
+	}{
+		{"next set", b.NextSet(4), 64},
+		{"next set past end", b.NextSet(201), -1},
+		{"next clear", b.NextClear(64), 66},
+		{"next clear past end", b.NextClear(500), 500},
+		{"rank", b.Rank(65), 2},
+		{"rank all", b.Rank(1 << 20), 4},
+		{"select", b.Select(2), 65},
+		{"select out of range", b.Select(4), -1},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, tt.got)
+		}
+	}
+
+	for k := range b.Count() {
+		if b.Rank(b.Select(k)) != k {
+			t.Errorf("expected Rank to invert Select for %d", k)
+		}
+	}
+}
+
+func TestBitSetOps(t *testing.T) {
+	a := NewBitSet(1, 2, 100)
+	b := NewBitSet(2, 3)
+
+	if got := a.DeepCopy().(*BitSet).Union(b); got.String() != "BitSet[1, 2, 3, 100]" {
+		t.Errorf("unexpected union: %s", got)
+	}
+
+	if got := a.DeepCopy().(*BitSet).Intersection(b); !got.Equals(NewBitSet(2)) {
+		t.Errorf("unexpected intersection: %s", got)
+	}
+
+	if got := a.DeepCopy().(*BitSet).Difference(b); !got.Equals(NewBitSet(1, 100)) {
+		t.Errorf("unexpected difference: %s", got)
+	}
+
+	s := a.ToSet()
+	if s.Size() != 3 || !BitSetOf(s).Equals(a) {
+		t.Errorf("unexpected round trip through %s", s)
+	}
+}
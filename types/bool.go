@@ -3,6 +3,7 @@ package types
 import (
 	"iter"
 
+	"github.com/PlayerR9/GoSD/event"
 	"github.com/PlayerR9/GoSD/pkg"
 )
 
@@ -10,10 +11,22 @@ import (
 type Bool struct {
 	// value is the bool value.
 	value bool
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
 }
 
 // String implements the pkg.Type interface.
 func (b *Bool) String() string {
+	b.poison.Check("b")
+
 	if b.value {
 		return "T"
 	} else {
@@ -23,6 +36,8 @@ func (b *Bool) String() string {
 
 // DeepCopy implements the pkg.Type interface.
 func (b *Bool) DeepCopy() pkg.Type {
+	b.poison.Check("b")
+
 	return &Bool{
 		value: b.value,
 	}
@@ -31,10 +46,21 @@ func (b *Bool) DeepCopy() pkg.Type {
 // Ensure implements the pkg.Type interface.
 func (b *Bool) Ensure() {
 	pkg.ThrowIf(b == nil, pkg.NewInvalidState("b", pkg.NewNilValue()))
+
+	b.poison.Check("b")
 }
 
 // Clean implements the pkg.Type interface.
-func (b *Bool) Clean() {}
+func (b *Bool) Clean() {
+	if b == nil {
+		return
+	}
+
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	b.poison.Mark()
+	b.observers = nil
+}
 
 // Equals implements the pkg.Type interface.
 //
@@ -75,8 +101,16 @@ func (b *Bool) WithValue(value bool) *Bool {
 		}
 	}
 
+	b.poison.Check("b")
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	old := b.value
 	b.value = value
 
+	if b.observers != nil {
+		b.observers.Notify(event.Set[bool]{Index: -1, Old: old, New: value})
+	}
+
 	return b
 }
 
@@ -85,17 +119,48 @@ func (b *Bool) WithValue(value bool) *Bool {
 // Returns:
 //   - bool: The bool value.
 func (b Bool) Value() bool {
+	b.poison.Check("b")
+
 	return b.value
 }
 
+// Reset sets the bool value back to false.
+func (b *Bool) Reset() {
+	if b == nil {
+		return
+	}
+
+	b.poison.Check("b")
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
+
+	old := b.value
+	b.value = false
+
+	if b.observers != nil {
+		b.observers.Notify(event.Set[bool]{Index: -1, Old: old, New: false})
+	}
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (b *Bool) Recycle(key *pkg.RecycleKey) {
+	b.poison.Revive(key)
+	b.Reset()
+}
+
 // Set sets the bool value.
 //
 // Parameters:
 //   - value: The new value.
 func (b *Bool) Set(value bool) {
 	pkg.Ensure(false, b)
+	pkg.ThrowIf(b.frozen, pkg.NewFrozen("b"))
 
+	old := b.value
 	b.value = value
+
+	if b.observers != nil {
+		b.observers.Notify(event.Set[bool]{Index: -1, Old: old, New: value})
+	}
 }
 
 // Each creates an iterator that iterates over the bool.
@@ -118,3 +183,70 @@ func (b *Bool) Each() iter.Seq[*Bool] {
 
 	return fn
 }
+
+// Hash implements the pkg.Hasher interface.
+func (b Bool) Hash() uint64 {
+	b.poison.Check("b")
+
+	if b.value {
+		return 1
+	}
+
+	return 0
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (b *Bool) Freeze() {
+	pkg.Ensure(false, b)
+
+	b.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (b Bool) IsFrozen() bool {
+	return b.frozen
+}
+
+// Subscribe subscribes to the changes of the bool.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Set[bool] changes, synchronously after
+//     the mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (b *Bool) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, b)
+
+	if b.observers == nil {
+		b.observers = &event.Observers{}
+	}
+
+	return b.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the bool into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (b *Bool) Batch(fn func()) {
+	pkg.Ensure(false, b)
+
+	b.observers.Batch(fn)
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Set[bool].
+func (b *Bool) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, b)
+
+	change, ok := c.(event.Set[bool])
+	pkg.ThrowIf(!ok, event.NewNotRevertible(c, b))
+
+	b.Set(change.Old)
+
+	return event.Set[bool]{Index: -1, Old: change.New, New: change.Old}
+}
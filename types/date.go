@@ -0,0 +1,275 @@
+package types
+
+import (
+	"iter"
+	"time"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// date_layout is the RFC 3339 full-date layout.
+const date_layout string = time.DateOnly
+
+// seconds_per_day is the number of seconds in a calendar day, in UTC.
+const seconds_per_day int64 = 24 * 60 * 60
+
+// Date is a calendar date without time of day nor time zone. Its arithmetic
+// methods update the date in place.
+type Date struct {
+	// value is the midnight of the date, in UTC.
+	value time.Time
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The date is written in RFC 3339 full-date format, as in "2006-01-02".
+func (d *Date) String() string {
+	d.poison.Check("d")
+
+	return d.value.Format(date_layout)
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (d *Date) DeepCopy() pkg.Type {
+	d.poison.Check("d")
+
+	return &Date{
+		value: d.value,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (d *Date) Ensure() {
+	pkg.ThrowIf(d == nil, pkg.NewInvalidState("d", pkg.NewNilValue()))
+
+	d.poison.Check("d")
+}
+
+// Clean implements the pkg.Type interface.
+func (d *Date) Clean() {
+	if d == nil {
+		return
+	}
+
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+
+	d.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two dates are equal if they are the same calendar day.
+func (d *Date) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Date:
+		return d.value.Equal(other.value)
+	default:
+		return false
+	}
+}
+
+// NewDate creates a new date. Out-of-range values are normalized, as in
+// time.Date: October 32 is November 1.
+//
+// Parameters:
+//   - year: The year.
+//   - month: The month.
+//   - day: The day of the month.
+//
+// Returns:
+//   - *Date: The new date. Never returns nil.
+func NewDate(year int, month time.Month, day int) *Date {
+	return &Date{
+		value: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// DateOf returns the calendar date of a time in its location.
+//
+// Parameters:
+//   - t: The time.
+//
+// Returns:
+//   - *Date: The date. Never returns nil.
+func DateOf(t time.Time) *Date {
+	year, month, day := t.Date()
+
+	return NewDate(year, month, day)
+}
+
+// ParseDate parses a date in RFC 3339 full-date format, as in "2006-01-02".
+//
+// Parameters:
+//   - str: The string to parse.
+//
+// Returns:
+//   - *Date: The parsed date. Nil if an error occurred.
+//   - error: An error if the string is not a valid date.
+//
+// Errors:
+//   - *IllegalArgument: If the string is not a valid date.
+func ParseDate(str string) (*Date, error) {
+	value, err := time.Parse(date_layout, str)
+	if err != nil {
+		return nil, pkg.NewIllegalArgument(err)
+	}
+
+	return &Date{
+		value: value,
+	}, nil
+}
+
+// Year returns the year of the date.
+//
+// Returns:
+//   - int: The year.
+func (d *Date) Year() int {
+	d.poison.Check("d")
+
+	return d.value.Year()
+}
+
+// Month returns the month of the date.
+//
+// Returns:
+//   - time.Month: The month.
+func (d *Date) Month() time.Month {
+	d.poison.Check("d")
+
+	return d.value.Month()
+}
+
+// Day returns the day of the month of the date.
+//
+// Returns:
+//   - int: The day of the month.
+func (d *Date) Day() int {
+	d.poison.Check("d")
+
+	return d.value.Day()
+}
+
+// Weekday returns the day of the week of the date.
+//
+// Returns:
+//   - time.Weekday: The day of the week.
+func (d *Date) Weekday() time.Weekday {
+	d.poison.Check("d")
+
+	return d.value.Weekday()
+}
+
+// In returns the midnight of the date in a location.
+//
+// Parameters:
+//   - loc: The location. If nil, UTC is used.
+//
+// Returns:
+//   - *Time: The time. Never returns nil.
+func (d *Date) In(loc *time.Location) *Time {
+	pkg.Ensure(false, d)
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	year, month, day := d.value.Date()
+
+	return NewTime(time.Date(year, month, day, 0, 0, 0, 0, loc))
+}
+
+// AddDays moves the date by a number of days.
+//
+// Parameters:
+//   - n: The number of days. Negative to move backward.
+//
+// Returns:
+//   - *Date: The date. Never returns nil.
+func (d *Date) AddDays(n int) *Date {
+	pkg.Ensure(false, d)
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+
+	d.value = d.value.AddDate(0, 0, n)
+
+	return d
+}
+
+// Sub returns the number of days from another date to this one.
+//
+// Parameters:
+//   - other: The other date.
+//
+// Returns:
+//   - int: The number of days. Negative if other is after the date.
+//
+// Panics if other is nil.
+func (d *Date) Sub(other *Date) int {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	return int((d.value.Unix() - other.value.Unix()) / seconds_per_day)
+}
+
+// Each returns an iterator over the dates from this one, included, to end,
+// excluded, one day at a time. The dates are new values.
+//
+// Parameters:
+//   - end: The date right after the last one.
+//
+// Returns:
+//   - iter.Seq[*Date]: The iterator. Never returns nil; it yields nothing if
+//     end is not after the date.
+//
+// Panics if end is nil.
+func (d *Date) Each(end *Date) iter.Seq[*Date] {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, end)
+
+	start, stop := d.value, end.value
+
+	fn := func(yield func(*Date) bool) {
+		for day := start; day.Before(stop); day = day.AddDate(0, 0, 1) {
+			if !yield(&Date{value: day}) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Compare implements the pkg.Comparer interface.
+func (d *Date) Compare(other *Date) int {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	return d.value.Compare(other.value)
+}
+
+// Hash implements the pkg.Hasher interface.
+func (d *Date) Hash() uint64 {
+	d.poison.Check("d")
+
+	return uint64(d.value.Unix() / seconds_per_day)
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (d *Date) Freeze() {
+	pkg.Ensure(false, d)
+
+	d.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (d *Date) IsFrozen() bool {
+	return d.frozen
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+func TestUseAfterClean(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	s := NewSet[*Int]().WithValue([]*Int{NewInt().WithValue(1)})
+	s.Clean()
+
+	defer func() {
+		r := recover()
+
+		err, ok := r.(error)
+		if !ok {
+			t.Fatalf("expected an error, got %v", r)
+		}
+
+		var target *pkg.Err
+
+		if !errors.As(err, &target) || target.Code != pkg.InvalidState {
+			t.Fatalf("expected an InvalidState error, got %v", err)
+		}
+
+		if !errors.Is(err, pkg.ErrUsedAfterClean) {
+			t.Errorf("expected %v, got %v", pkg.ErrUsedAfterClean, err)
+		}
+
+		if !strings.Contains(err.Error(), "TestUseAfterClean") {
+			t.Errorf("expected the stack of the Clean call, got %v", err)
+		}
+	}()
+
+	s.Add(NewInt().WithValue(2))
+
+	t.Fatalf("expected a panic")
+}
+
+func TestResetAfterClean(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	x := NewInt().WithValue(1)
+	x.Clean()
+
+	if code := code_of(x.Reset); code != pkg.InvalidState {
+		t.Errorf("expected Reset to throw InvalidState, got %v", code)
+	}
+
+	if code := code_of(func() { x.Recycle(&pkg.RecycleKey{}) }); code != pkg.InvalidCall {
+		t.Errorf("expected a forged key to throw InvalidCall, got %v", code)
+	}
+
+	pool := pkg.NewPool(NewInt).WithDebug(true)
+
+	y := pool.Get()
+	y.Set(3)
+	pool.Put(y)
+
+	if y = pool.Get(); y.Value() != 0 {
+		t.Errorf("expected the pool to revive and reset the value, got %s", y)
+	}
+}
@@ -0,0 +1,497 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+//go:generate stringer -type=RoundingMode
+
+// RoundingMode is the way a Decimal rounds the results that have more
+// fractional digits than its scale.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest value, and ties to the even one.
+	// It is the default rounding mode.
+	RoundHalfEven RoundingMode = iota
+
+	// RoundHalfUp rounds to the nearest value, and ties away from zero.
+	RoundHalfUp
+
+	// RoundHalfDown rounds to the nearest value, and ties toward zero.
+	RoundHalfDown
+
+	// RoundUp rounds away from zero.
+	RoundUp
+
+	// RoundDown rounds toward zero, that is, truncates.
+	RoundDown
+
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling
+
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+)
+
+// Decimal is a fixed-scale decimal number: it always has scale digits after
+// the decimal point. Its arithmetic methods update the number in place and
+// round the exact result to the scale with the rounding mode of the number.
+type Decimal struct {
+	// unscaled is the value multiplied by 10^scale.
+	unscaled big.Int
+
+	// scale is the number of digits after the decimal point.
+	scale int
+
+	// mode is the rounding mode.
+	mode RoundingMode
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The number is written with exactly scale digits after the decimal point,
+// and no point if the scale is 0; for instance "-1.50" for a scale of 2.
+func (d *Decimal) String() string {
+	d.poison.Check("d")
+
+	digits := new(big.Int).Abs(&d.unscaled).String()
+
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+
+	var builder strings.Builder
+
+	if d.unscaled.Sign() < 0 {
+		builder.WriteByte('-')
+	}
+
+	point := len(digits) - d.scale
+
+	builder.WriteString(digits[:point])
+
+	if d.scale > 0 {
+		builder.WriteByte('.')
+		builder.WriteString(digits[point:])
+	}
+
+	return builder.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (d *Decimal) DeepCopy() pkg.Type {
+	d.poison.Check("d")
+
+	d_copy := &Decimal{
+		scale: d.scale,
+		mode:  d.mode,
+	}
+
+	d_copy.unscaled.Set(&d.unscaled)
+
+	return d_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (d *Decimal) Ensure() {
+	pkg.ThrowIf(d == nil, pkg.NewInvalidState("d", pkg.NewNilValue()))
+
+	d.poison.Check("d")
+}
+
+// Clean implements the pkg.Type interface.
+func (d *Decimal) Clean() {
+	if d == nil {
+		return
+	}
+
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+
+	d.unscaled.SetInt64(0)
+
+	d.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two decimals are equal if they have the same value and the same scale,
+// regardless of their rounding mode, so that equal decimals have the same
+// String form: 1.5 does not equal 1.50. Use Compare to compare the values
+// alone.
+func (d *Decimal) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Decimal:
+		return d.scale == other.scale && d.unscaled.Cmp(&other.unscaled) == 0
+	default:
+		return false
+	}
+}
+
+// NewDecimal creates a new decimal set to 0.
+//
+// Parameters:
+//   - scale: The number of digits after the decimal point.
+//
+// Returns:
+//   - *Decimal: The new decimal. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If scale is negative.
+func NewDecimal(scale int) *Decimal {
+	pkg.ThrowIf(scale < 0, pkg.NewIllegalArgument(errors.New("scale must not be negative")))
+
+	return &Decimal{
+		scale: scale,
+	}
+}
+
+// ParseDecimal parses a decimal number such as "-1.25" or "3e-2", and rounds
+// it to the scale with RoundHalfEven.
+//
+// Parameters:
+//   - str: The string to parse.
+//   - scale: The number of digits after the decimal point.
+//
+// Returns:
+//   - *Decimal: The decimal. Nil if an error occurred.
+//   - error: An error if the string is not a decimal number.
+//
+// Errors:
+//   - *IllegalArgument: If the string is not a decimal number or scale is
+//     negative.
+func ParseDecimal(str string, scale int) (*Decimal, error) {
+	if scale < 0 {
+		return nil, pkg.NewIllegalArgument(errors.New("scale must not be negative"))
+	}
+
+	var r big.Rat
+
+	_, ok := r.SetString(str)
+	if !ok || strings.Contains(str, "/") {
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("%q is not a decimal number", str))
+	}
+
+	d := &Decimal{
+		scale: scale,
+	}
+
+	d.set_rat(&r)
+
+	return d, nil
+}
+
+// WithRounding sets the rounding mode of the decimal.
+//
+// Parameters:
+//   - mode: The rounding mode.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+func (d *Decimal) WithRounding(mode RoundingMode) *Decimal {
+	pkg.Ensure(false, d)
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+
+	d.mode = mode
+
+	return d
+}
+
+// Scale returns the number of digits after the decimal point.
+//
+// Returns:
+//   - int: The scale.
+func (d *Decimal) Scale() int {
+	pkg.Ensure(false, d)
+
+	return d.scale
+}
+
+// Rounding returns the rounding mode of the decimal.
+//
+// Returns:
+//   - RoundingMode: The rounding mode.
+func (d *Decimal) Rounding() RoundingMode {
+	pkg.Ensure(false, d)
+
+	return d.mode
+}
+
+// Rat returns the value as a rational number.
+//
+// Returns:
+//   - *Rat: The value. Never returns nil.
+func (d *Decimal) Rat() *Rat {
+	pkg.Ensure(false, d)
+
+	r := &Rat{}
+	r.value.Set(d.rat())
+
+	return r
+}
+
+// Sign returns the sign of the value.
+//
+// Returns:
+//   - int: -1 if the value is negative, 0 if it is zero and 1 otherwise.
+func (d *Decimal) Sign() int {
+	pkg.Ensure(false, d)
+
+	return d.unscaled.Sign()
+}
+
+// Compare compares the decimal with another one, regardless of their scale.
+// It can be used to sort decimals with slices.SortFunc. Unlike Equals, it
+// reports 1.5 and 1.50 as equal.
+//
+// Parameters:
+//   - other: The other decimal.
+//
+// Returns:
+//   - int: -1 if d < other, 0 if d == other and 1 if d > other.
+//
+// Panics if other is nil.
+func (d *Decimal) Compare(other *Decimal) int {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	if d.scale == other.scale {
+		return d.unscaled.Cmp(&other.unscaled)
+	}
+
+	return d.rat().Cmp(other.rat())
+}
+
+// rat returns the exact value of the decimal.
+//
+// Returns:
+//   - *big.Rat: The value. Never returns nil.
+func (d *Decimal) rat() *big.Rat {
+	return new(big.Rat).SetFrac(&d.unscaled, pow10(d.scale))
+}
+
+// set_rat sets the value to r rounded to the scale.
+//
+// Parameters:
+//   - r: The exact value.
+func (d *Decimal) set_rat(r *big.Rat) {
+	scaled := new(big.Int).Mul(r.Num(), pow10(d.scale))
+
+	q, m := new(big.Int).QuoRem(scaled, r.Denom(), new(big.Int))
+
+	if m.Sign() != 0 && round_away(q, m, r.Denom(), scaled.Sign(), d.mode) {
+		q.Add(q, big.NewInt(int64(scaled.Sign())))
+	}
+
+	d.unscaled.Set(q)
+}
+
+// round_away checks whether a truncated quotient must be rounded away from
+// zero.
+//
+// Parameters:
+//   - q: The quotient, truncated toward zero.
+//   - m: The non-zero remainder.
+//   - denom: The positive divisor.
+//   - sign: The sign of the exact result.
+//   - mode: The rounding mode.
+//
+// Returns:
+//   - bool: True if the quotient must be rounded away from zero, false
+//     otherwise.
+func round_away(q, m, denom *big.Int, sign int, mode RoundingMode) bool {
+	// half compares the discarded fraction with one half.
+	twice := new(big.Int).Abs(m)
+	twice.Lsh(twice, 1)
+
+	half := twice.Cmp(denom)
+
+	switch mode {
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfDown:
+		return half > 0
+	case RoundUp:
+		return true
+	case RoundDown:
+		return false
+	case RoundCeiling:
+		return sign > 0
+	case RoundFloor:
+		return sign < 0
+	default:
+		return half > 0 || (half == 0 && q.Bit(0) == 1)
+	}
+}
+
+// pow10 returns 10^n.
+//
+// Parameters:
+//   - n: The exponent. It must not be negative.
+//
+// Returns:
+//   - *big.Int: 10^n. Never returns nil.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// apply sets the decimal to the rounded result of an operation on the exact
+// values.
+//
+// Parameters:
+//   - other: The other operand.
+//   - op: The operation. It stores its result in its first argument.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+func (d *Decimal) apply(other *Decimal, op func(z, x, y *big.Rat) *big.Rat) *Decimal {
+	pkg.Ensure(false, d)
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+	pkg.Ensure(false, other)
+
+	r := d.rat()
+	op(r, r, other.rat())
+
+	d.set_rat(r)
+
+	return d
+}
+
+// Set sets the value from another decimal, rounded to the scale.
+//
+// Parameters:
+//   - other: The other decimal.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+func (d *Decimal) Set(other *Decimal) *Decimal {
+	return d.apply(other, func(z, _, y *big.Rat) *big.Rat {
+		return z.Set(y)
+	})
+}
+
+// Add adds other to the decimal.
+//
+// Parameters:
+//   - other: The value to add.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+func (d *Decimal) Add(other *Decimal) *Decimal {
+	return d.apply(other, (*big.Rat).Add)
+}
+
+// Sub subtracts other from the decimal.
+//
+// Parameters:
+//   - other: The value to subtract.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+func (d *Decimal) Sub(other *Decimal) *Decimal {
+	return d.apply(other, (*big.Rat).Sub)
+}
+
+// Mul multiplies the decimal by other.
+//
+// Parameters:
+//   - other: The value to multiply by.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+func (d *Decimal) Mul(other *Decimal) *Decimal {
+	return d.apply(other, (*big.Rat).Mul)
+}
+
+// Quo divides the decimal by other.
+//
+// Parameters:
+//   - other: The value to divide by.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+//
+// Throws:
+//   - *DivisionByZero: If other is 0.
+func (d *Decimal) Quo(other *Decimal) *Decimal {
+	pkg.Ensure(false, other)
+	pkg.ThrowIf(other.unscaled.Sign() == 0, pkg.NewDivisionByZero())
+
+	return d.apply(other, (*big.Rat).Quo)
+}
+
+// Neg negates the decimal.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+func (d *Decimal) Neg() *Decimal {
+	pkg.Ensure(false, d)
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+
+	d.unscaled.Neg(&d.unscaled)
+
+	return d
+}
+
+// Abs sets the decimal to its absolute value.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+func (d *Decimal) Abs() *Decimal {
+	pkg.Ensure(false, d)
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+
+	d.unscaled.Abs(&d.unscaled)
+
+	return d
+}
+
+// Rescale changes the scale of the decimal, rounding the value with the
+// rounding mode if the scale decreases.
+//
+// Parameters:
+//   - scale: The new scale.
+//
+// Returns:
+//   - *Decimal: The decimal. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If scale is negative.
+func (d *Decimal) Rescale(scale int) *Decimal {
+	pkg.Ensure(false, d)
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+	pkg.ThrowIf(scale < 0, pkg.NewIllegalArgument(errors.New("scale must not be negative")))
+
+	r := d.rat()
+
+	d.scale = scale
+	d.set_rat(r)
+
+	return d
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (d *Decimal) Freeze() {
+	pkg.Ensure(false, d)
+
+	d.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (d *Decimal) IsFrozen() bool {
+	return d.frozen
+}
@@ -0,0 +1,232 @@
+package types
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Duration is a span of time, as a time.Duration. Its arithmetic methods update
+// the duration in place and throw on overflow.
+type Duration struct {
+	// value is the duration value.
+	value time.Duration
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The duration is written as time.Duration does, as in "1h2m3.5s".
+func (d *Duration) String() string {
+	d.poison.Check("d")
+
+	return d.value.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (d *Duration) DeepCopy() pkg.Type {
+	d.poison.Check("d")
+
+	return &Duration{
+		value: d.value,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (d *Duration) Ensure() {
+	pkg.ThrowIf(d == nil, pkg.NewInvalidState("d", pkg.NewNilValue()))
+
+	d.poison.Check("d")
+}
+
+// Clean implements the pkg.Type interface.
+func (d *Duration) Clean() {
+	if d == nil {
+		return
+	}
+
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+
+	d.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two durations are equal if they have the same length.
+func (d *Duration) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Duration:
+		return d.value == other.value
+	default:
+		return false
+	}
+}
+
+// NewDuration creates a new duration.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *Duration: The new duration. Never returns nil.
+func NewDuration(value time.Duration) *Duration {
+	return &Duration{
+		value: value,
+	}
+}
+
+// ParseDuration parses a duration in the format of time.ParseDuration, such
+// as "1h30m" or "-2.5s".
+//
+// Parameters:
+//   - str: The string to parse.
+//
+// Returns:
+//   - *Duration: The parsed duration. Nil if an error occurred.
+//   - error: An error if the string is not a valid duration.
+//
+// Errors:
+//   - *IllegalArgument: If the string is not a valid duration.
+func ParseDuration(str string) (*Duration, error) {
+	value, err := time.ParseDuration(str)
+	if err != nil {
+		return nil, pkg.NewIllegalArgument(err)
+	}
+
+	return NewDuration(value), nil
+}
+
+// Value returns the duration value.
+//
+// Returns:
+//   - time.Duration: The duration value.
+func (d *Duration) Value() time.Duration {
+	d.poison.Check("d")
+
+	return d.value
+}
+
+// Set sets the duration value.
+//
+// Parameters:
+//   - value: The new value.
+//
+// Returns:
+//   - *Duration: The duration. Never returns nil.
+func (d *Duration) Set(value time.Duration) *Duration {
+	pkg.Ensure(false, d)
+	pkg.ThrowIf(d.frozen, pkg.NewFrozen("d"))
+
+	d.value = value
+
+	return d
+}
+
+// Add adds another duration.
+//
+// Parameters:
+//   - other: The other duration.
+//
+// Returns:
+//   - *Duration: The duration. Never returns nil.
+//
+// Throws:
+//   - *Overflow: If the sum does not fit in a time.Duration.
+//
+// Panics if other is nil.
+func (d *Duration) Add(other *Duration) *Duration {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	sum := d.value + other.value
+	pkg.ThrowIf((sum > d.value) != (other.value > 0), pkg.NewOverflow(errors.New("duration sum overflows")))
+
+	return d.Set(sum)
+}
+
+// Sub subtracts another duration.
+//
+// Parameters:
+//   - other: The other duration.
+//
+// Returns:
+//   - *Duration: The duration. Never returns nil.
+//
+// Throws:
+//   - *Overflow: If the difference does not fit in a time.Duration.
+//
+// Panics if other is nil.
+func (d *Duration) Sub(other *Duration) *Duration {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	diff := d.value - other.value
+	pkg.ThrowIf((diff < d.value) != (other.value > 0), pkg.NewOverflow(errors.New("duration difference overflows")))
+
+	return d.Set(diff)
+}
+
+// Truncate rounds the duration toward zero to a multiple of m.
+//
+// Parameters:
+//   - m: The multiple. If not positive, the duration is left unchanged.
+//
+// Returns:
+//   - *Duration: The duration. Never returns nil.
+func (d *Duration) Truncate(m time.Duration) *Duration {
+	pkg.Ensure(false, d)
+
+	return d.Set(d.value.Truncate(m))
+}
+
+// Abs makes the duration non-negative.
+//
+// Returns:
+//   - *Duration: The duration. Never returns nil.
+//
+// Throws:
+//   - *Overflow: If the duration is math.MinInt64 nanoseconds.
+func (d *Duration) Abs() *Duration {
+	pkg.Ensure(false, d)
+	pkg.ThrowIf(d.value == math.MinInt64, pkg.NewOverflow(errors.New("duration absolute value overflows")))
+
+	return d.Set(d.value.Abs())
+}
+
+// Compare implements the pkg.Comparer interface.
+func (d *Duration) Compare(other *Duration) int {
+	pkg.Ensure(false, d)
+	pkg.Ensure(false, other)
+
+	return cmp.Compare(d.value, other.value)
+}
+
+// Hash implements the pkg.Hasher interface.
+func (d *Duration) Hash() uint64 {
+	d.poison.Check("d")
+
+	return uint64(d.value)
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (d *Duration) Freeze() {
+	pkg.Ensure(false, d)
+
+	d.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (d *Duration) IsFrozen() bool {
+	return d.frozen
+}
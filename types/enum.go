@@ -1,6 +1,13 @@
 package types
 
-import "github.com/PlayerR9/GoSD/pkg"
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+
+	"github.com/PlayerR9/GoSD/event"
+	"github.com/PlayerR9/GoSD/pkg"
+)
 
 type Enumer interface {
 	~int
@@ -16,27 +23,64 @@ type Enumer interface {
 type Enum[T Enumer] struct {
 	// value is the enum value.
 	value T
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
 }
 
 // String implements the pkg.Type interface.
 func (e *Enum[T]) String() string {
+	e.poison.Check("e")
+
 	return e.value.String()
 }
 
 // DeepCopy implements the pkg.Type interface.
 func (e *Enum[T]) DeepCopy() pkg.Type {
+	e.poison.Check("e")
+
 	return &Enum[T]{
 		value: e.value,
 	}
 }
 
 // Ensure implements the pkg.Type interface.
+//
+// Throws:
+//   - *IllegalArgument: If a value set is registered for T and the value is
+//     not part of it.
 func (e *Enum[T]) Ensure() {
+	e.check()
+
+	pkg.ThrowIf(!IsEnumValue(e.value), new_invalid_enum(e.value))
+}
+
+// check ensures the enum is neither nil nor cleaned, without validating its
+// value, so that an invalid value can still be replaced.
+func (e *Enum[T]) check() {
 	pkg.ThrowIf(e == nil, pkg.NewInvalidState("e", pkg.NewNilValue()))
+
+	e.poison.Check("e")
 }
 
 // Clean implements the pkg.Type interface.
-func (e *Enum[T]) Clean() {}
+func (e *Enum[T]) Clean() {
+	if e == nil {
+		return
+	}
+
+	pkg.ThrowIf(e.frozen, pkg.NewFrozen("e"))
+
+	e.poison.Mark()
+	e.observers = nil
+}
 
 // Equals implements the pkg.Type interface.
 //
@@ -60,7 +104,13 @@ func (e *Enum[T]) Equals(other pkg.Type) bool {
 //
 // Returns:
 //   - *Enum: The new enum. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If a value set is registered for T and value is not
+//     part of it.
 func NewEnum[T Enumer](value T) *Enum[T] {
+	pkg.ThrowIf(!IsEnumValue(value), new_invalid_enum(value))
+
 	return &Enum[T]{
 		value: value,
 	}
@@ -71,6 +121,8 @@ func NewEnum[T Enumer](value T) *Enum[T] {
 // Returns:
 //   - T: The enum value.
 func (e Enum[T]) Value() T {
+	e.poison.Check("e")
+
 	return e.value
 }
 
@@ -78,8 +130,179 @@ func (e Enum[T]) Value() T {
 //
 // Parameters:
 //   - value: The new value.
+//
+// Throws:
+//   - *IllegalArgument: If a value set is registered for T and value is not
+//     part of it.
 func (e *Enum[T]) Set(value T) {
-	pkg.Ensure(false, e)
+	e.check()
+	pkg.ThrowIf(e.frozen, pkg.NewFrozen("e"))
+
+	pkg.ThrowIf(!IsEnumValue(value), new_invalid_enum(value))
 
+	old := e.value
 	e.value = value
+
+	if e.observers != nil {
+		e.observers.Notify(event.Set[T]{Index: -1, Old: old, New: value})
+	}
+}
+
+// step moves the value by n positions in the registered value set, wrapping
+// around at both ends.
+//
+// Parameters:
+//   - n: The number of positions.
+func (e *Enum[T]) step(n int) {
+	e.check()
+	pkg.ThrowIf(e.frozen, pkg.NewFrozen("e"))
+
+	values, ok := enum_values[T]()
+	pkg.ThrowIf(!ok, pkg.NewInvalidState("e", fmt.Errorf("no values are registered for %v", reflect.TypeFor[T]())))
+
+	i := enum_index(values, e.value)
+	pkg.ThrowIf(i < 0, new_invalid_enum(e.value))
+
+	i = ((i+n)%len(values) + len(values)) % len(values)
+
+	e.Set(values[i])
+}
+
+// Next moves the enum to the next registered value, going back to the first
+// one after the last.
+//
+// Returns:
+//   - *Enum[T]: The receiver. Never returns nil.
+//
+// Throws:
+//   - *InvalidState: If no value set is registered for T.
+//   - *IllegalArgument: If the value is not part of the value set.
+func (e *Enum[T]) Next() *Enum[T] {
+	e.step(1)
+
+	return e
+}
+
+// Prev moves the enum to the previous registered value, going to the last
+// one before the first.
+//
+// Returns:
+//   - *Enum[T]: The receiver. Never returns nil.
+//
+// Throws:
+//   - *InvalidState: If no value set is registered for T.
+//   - *IllegalArgument: If the value is not part of the value set.
+func (e *Enum[T]) Prev() *Enum[T] {
+	e.step(-1)
+
+	return e
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+//
+// The enum is written by name, which encoding/json uses as well.
+//
+// Errors:
+//   - *IllegalArgument: If a value set is registered for T and the value is
+//     not part of it.
+func (e *Enum[T]) MarshalText() ([]byte, error) {
+	e.check()
+
+	if !IsEnumValue(e.value) {
+		return nil, new_invalid_enum(e.value)
+	}
+
+	return []byte(e.value.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+//
+// Errors:
+//   - *IllegalArgument: If no value set is registered for T or if no value has
+//     the given name.
+func (e *Enum[T]) UnmarshalText(text []byte) error {
+	e.check()
+	pkg.ThrowIf(e.frozen, pkg.NewFrozen("e"))
+
+	value, err := enum_by_name[T](string(text))
+	if err != nil {
+		return err
+	}
+
+	e.Set(value)
+
+	return nil
+}
+
+// Compare implements the pkg.Comparer interface.
+//
+// The enums are compared by their underlying value.
+func (e *Enum[T]) Compare(other *Enum[T]) int {
+	pkg.Ensure(false, e)
+	pkg.Ensure(false, other)
+
+	return cmp.Compare(e.value, other.value)
+}
+
+// Hash implements the pkg.Hasher interface.
+func (e Enum[T]) Hash() uint64 {
+	e.poison.Check("e")
+
+	return uint64(e.value)
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (e *Enum[T]) Freeze() {
+	pkg.Ensure(false, e)
+
+	e.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (e Enum[T]) IsFrozen() bool {
+	return e.frozen
+}
+
+// Subscribe subscribes to the changes of the enum.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Set[T] changes,
+//     synchronously after the mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (e *Enum[T]) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, e)
+
+	if e.observers == nil {
+		e.observers = &event.Observers{}
+	}
+
+	return e.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the enum into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (e *Enum[T]) Batch(fn func()) {
+	pkg.Ensure(false, e)
+
+	e.observers.Batch(fn)
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Set[T].
+func (e *Enum[T]) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, e)
+
+	change, ok := c.(event.Set[T])
+	pkg.ThrowIf(!ok, event.NewNotRevertible(c, e))
+
+	e.Set(change.Old)
+
+	return event.Set[T]{Index: -1, Old: change.New, New: change.Old}
 }
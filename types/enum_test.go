@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+type suit int
+
+const (
+	clubs suit = iota
+	diamonds
+	hearts
+	spades
+)
+
+func (s suit) String() string {
+	return [...]string{"clubs", "diamonds", "hearts", "spades"}[s]
+}
+
+func init() {
+	RegisterEnumValues(clubs, diamonds, hearts, spades)
+}
+
+func TestEnumValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"new", func() { NewEnum(suit(9)) }},
+		{"set", func() { NewEnum(hearts).Set(suit(-1)) }},
+		{"duplicate", func() { RegisterEnumValues(clubs, clubs) }},
+	}
+
+	for _, tt := range tests {
+		if code := code_of(tt.fn); code != pkg.IllegalArgument {
+			t.Errorf("%s: expected IllegalArgument, got %v", tt.name, code)
+		}
+	}
+}
+
+func TestParseEnum(t *testing.T) {
+	e, err := ParseEnum[suit]("hearts")
+	if err != nil || e.Value() != hearts {
+		t.Fatalf("expected hearts, got %v, %v", e, err)
+	}
+
+	if _, err := ParseEnum[suit]("stars"); err == nil {
+		t.Errorf("expected an error for an unknown name")
+	}
+
+	var names []string
+	for v := range EnumValues[suit]() {
+		names = append(names, v.String())
+	}
+
+	if len(names) != 4 || names[3] != "spades" {
+		t.Errorf("unexpected values: %v", names)
+	}
+}
+
+func TestEnumCycle(t *testing.T) {
+	e := NewEnum(spades)
+
+	if e.Next().Value() != clubs {
+		t.Errorf("expected to wrap to clubs, got %s", e)
+	}
+
+	if e.Prev().Prev().Value() != hearts {
+		t.Errorf("expected to wrap back to hearts, got %s", e)
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	data, err := json.Marshal(map[string]*Enum[suit]{"card": NewEnum(diamonds)})
+	if err != nil || string(data) != `{"card":"diamonds"}` {
+		t.Fatalf("unexpected JSON: %s, %v", data, err)
+	}
+
+	var got map[string]*Enum[suit]
+	if err := json.Unmarshal(data, &got); err != nil || got["card"].Value() != diamonds {
+		t.Errorf("unexpected round trip: %v, %v", got, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"card":"stars"}`), &got); err == nil {
+		t.Errorf("expected an error for an unknown name")
+	}
+}
@@ -0,0 +1,166 @@
+package types
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"sync"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// enum_domains maps the Go type of every Enumer with a registered value set to
+// its values, stored as a []T.
+var enum_domains sync.Map
+
+// RegisterEnumValues registers the valid values of an Enumer type, replacing
+// any previous registration. Once registered, Enum[T] rejects the other
+// values and can be parsed, iterated and marshalled by name.
+//
+// Parameters:
+//   - values: The valid values, in the order EnumValues, Next and Prev use.
+//
+// Throws:
+//   - *IllegalArgument: If values is empty, or if two values are equal or have
+//     the same name.
+func RegisterEnumValues[T Enumer](values ...T) {
+	pkg.ThrowIf(len(values) == 0, pkg.NewIllegalArgument(fmt.Errorf("no values given for %v", reflect.TypeFor[T]())))
+
+	for i, v := range values {
+		for _, prev := range values[:i] {
+			pkg.ThrowIf(v == prev || v.String() == prev.String(), pkg.NewIllegalArgument(fmt.Errorf("%v is given twice", v)))
+		}
+	}
+
+	enum_domains.Store(reflect.TypeFor[T](), append([]T(nil), values...))
+}
+
+// enum_values returns the registered values of an Enumer type.
+//
+// Returns:
+//   - []T: The values. Must not be modified.
+//   - bool: True if the values are registered, false otherwise.
+func enum_values[T Enumer]() ([]T, bool) {
+	values, ok := enum_domains.Load(reflect.TypeFor[T]())
+	if !ok {
+		return nil, false
+	}
+
+	return values.([]T), true
+}
+
+// EnumValues returns an iterator over the registered values of an Enumer
+// type, in the order they were registered.
+//
+// Returns:
+//   - iter.Seq[T]: The iterator. Never returns nil; it yields nothing if no
+//     value set is registered for T.
+func EnumValues[T Enumer]() iter.Seq[T] {
+	values, _ := enum_values[T]()
+
+	fn := func(yield func(T) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// IsEnumValue checks whether a value belongs to the registered value set of
+// its type.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - bool: True if the value is registered or if no value set is registered
+//     for T, false otherwise.
+func IsEnumValue[T Enumer](value T) bool {
+	values, ok := enum_values[T]()
+	if !ok {
+		return true
+	}
+
+	return enum_index(values, value) >= 0
+}
+
+// enum_index returns the position of a value in a value set.
+//
+// Parameters:
+//   - values: The value set.
+//   - value: The value.
+//
+// Returns:
+//   - int: The position, or -1 if the value is not in the set.
+func enum_index[T Enumer](values []T, value T) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// new_invalid_enum creates the error of a value outside its value set.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *pkg.Err: The error. Never returns nil.
+func new_invalid_enum[T Enumer](value T) *pkg.Err {
+	return pkg.NewIllegalArgument(fmt.Errorf("%d is not a valid %v", int(value), reflect.TypeFor[T]()))
+}
+
+// enum_by_name returns the registered value with the given name.
+//
+// Parameters:
+//   - name: The name.
+//
+// Returns:
+//   - T: The value.
+//   - error: An error if the value cannot be found.
+//
+// Errors:
+//   - *IllegalArgument: If no value set is registered for T or if no value has
+//     the given name.
+func enum_by_name[T Enumer](name string) (T, error) {
+	values, ok := enum_values[T]()
+	if !ok {
+		return *new(T), pkg.NewIllegalArgument(fmt.Errorf("no values are registered for %v", reflect.TypeFor[T]()))
+	}
+
+	for _, v := range values {
+		if v.String() == name {
+			return v, nil
+		}
+	}
+
+	return *new(T), pkg.NewIllegalArgument(fmt.Errorf("%q is not a valid %v", name, reflect.TypeFor[T]()))
+}
+
+// ParseEnum creates an enum from the name of one of the registered values of
+// T.
+//
+// Parameters:
+//   - name: The name, as returned by the String method of the value.
+//
+// Returns:
+//   - *Enum[T]: The new enum. Nil if an error occurred.
+//   - error: An error if the name is not valid.
+//
+// Errors:
+//   - *IllegalArgument: If no value set is registered for T or if no value has
+//     the given name.
+func ParseEnum[T Enumer](name string) (*Enum[T], error) {
+	value, err := enum_by_name[T](name)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEnum(value), nil
+}
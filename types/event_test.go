@@ -0,0 +1,73 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/event"
+)
+
+func TestSubscribe(t *testing.T) {
+	set := NewSet[*Int]()
+
+	var changes []event.Change
+
+	unsubscribe := set.Subscribe(func(c event.Change) {
+		changes = append(changes, c)
+	})
+
+	set.Add(NewInt().WithValue(1))
+	set.Add(NewInt().WithValue(1))
+
+	other := NewSet[*Int]().WithValue([]*Int{NewInt().WithValue(2), NewInt().WithValue(3)})
+	set.Union(other)
+
+	unsubscribe()
+
+	set.Reset()
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d: %v", len(changes), changes)
+	}
+
+	added, ok := changes[0].(event.Added[*Int])
+	if !ok || added.Elem.Value() != 1 {
+		t.Errorf("expected Added[elem=1], got %v", changes[0])
+	}
+
+	batch, ok := changes[1].(event.Batch)
+	if !ok || len(batch.Changes) != 2 {
+		t.Errorf("expected a batch of 2 changes, got %v", changes[1])
+	}
+}
+
+func TestBatch(t *testing.T) {
+	i := NewInt()
+
+	var changes []event.Change
+
+	i.Subscribe(func(c event.Change) {
+		changes = append(changes, c)
+	})
+
+	i.Batch(func() {
+		i.Set(1)
+	})
+
+	i.Batch(func() {
+		i.Set(2)
+		i.Set(3)
+	})
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d: %v", len(changes), changes)
+	}
+
+	set, ok := changes[0].(event.Set[int])
+	if !ok || set.Old != 0 || set.New != 1 {
+		t.Errorf("expected Set[old=0, new=1], got %v", changes[0])
+	}
+
+	if _, ok := changes[1].(event.Batch); !ok {
+		t.Errorf("expected a batch, got %v", changes[1])
+	}
+}
@@ -0,0 +1,429 @@
+package types
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"reflect"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Flags is a set of bit flags. The flags are the values of an Enumer type,
+// each of which must be a single bit, and the domain of the set is the value
+// set registered with RegisterEnumValues.
+type Flags[T Enumer] struct {
+	// mask is the union of the active flags.
+	mask T
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The active flags are written in the order of the value set, separated by
+// '|', as in "A|B|C". No flag is written as the empty string.
+func (f *Flags[T]) String() string {
+	f.poison.Check("f")
+
+	var names []string
+
+	for flag := range f.Each() {
+		names = append(names, flag.String())
+	}
+
+	return strings.Join(names, "|")
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (f *Flags[T]) DeepCopy() pkg.Type {
+	f.poison.Check("f")
+
+	return &Flags[T]{
+		mask: f.mask,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+//
+// Throws:
+//   - *IllegalArgument: If a flag outside the domain is active.
+func (f *Flags[T]) Ensure() {
+	pkg.ThrowIf(f == nil, pkg.NewInvalidState("f", pkg.NewNilValue()))
+
+	f.poison.Check("f")
+
+	domain, _ := flag_domain[T]()
+
+	extra := f.mask &^ domain
+	pkg.ThrowIf(extra != 0, new_invalid_enum(extra))
+}
+
+// Clean implements the pkg.Type interface.
+func (f *Flags[T]) Clean() {
+	if f == nil {
+		return
+	}
+
+	pkg.ThrowIf(f.frozen, pkg.NewFrozen("f"))
+
+	f.mask = 0
+
+	f.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two flag sets are equal if they have the same active flags.
+func (f *Flags[T]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, f)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Flags[T]:
+		return f.mask == other.mask
+	default:
+		return false
+	}
+}
+
+// flag_domain returns the domain of the flags of type T.
+//
+// Returns:
+//   - T: The union of all the flags.
+//   - []T: The flags, in the order they were registered.
+//
+// Throws:
+//   - *InvalidState: If no value set is registered for T.
+//   - *IllegalArgument: If a registered value is not a single bit.
+func flag_domain[T Enumer]() (T, []T) {
+	values, ok := enum_values[T]()
+	pkg.ThrowIf(!ok, pkg.NewInvalidState("T", fmt.Errorf("no values are registered for %v", reflect.TypeFor[T]())))
+
+	var domain T
+
+	for _, v := range values {
+		pkg.ThrowIf(v <= 0 || bits.OnesCount(uint(v)) != 1, pkg.NewIllegalArgument(fmt.Errorf("%v is not a single bit", v)))
+
+		domain |= v
+	}
+
+	return domain, values
+}
+
+// flag_mask returns the union of flags.
+//
+// Parameters:
+//   - flags: The flags.
+//
+// Returns:
+//   - T: The union.
+//
+// Throws:
+//   - *IllegalArgument: If a flag is not in the registered value set.
+func flag_mask[T Enumer](flags []T) T {
+	var mask T
+
+	for _, flag := range flags {
+		pkg.ThrowIf(!IsEnumValue(flag), new_invalid_enum(flag))
+
+		mask |= flag
+	}
+
+	return mask
+}
+
+// NewFlags creates a new flag set.
+//
+// Parameters:
+//   - flags: The active flags.
+//
+// Returns:
+//   - *Flags[T]: The new flag set. Never returns nil.
+//
+// Throws:
+//   - *InvalidState: If no value set is registered for T.
+//   - *IllegalArgument: If a registered value is not a single bit or if a flag
+//     is not in the value set.
+func NewFlags[T Enumer](flags ...T) *Flags[T] {
+	_, _ = flag_domain[T]()
+
+	return &Flags[T]{
+		mask: flag_mask(flags),
+	}
+}
+
+// ParseFlags parses a flag set from its "A|B|C" form. Spaces around the names
+// are ignored and the empty string is the empty set.
+//
+// Parameters:
+//   - str: The string to parse.
+//
+// Returns:
+//   - *Flags[T]: The parsed flag set. Nil if an error occurred.
+//   - error: An error if the string is not valid.
+//
+// Errors:
+//   - *IllegalArgument: If a name is not the one of a registered value.
+//
+// Throws under the same conditions as NewFlags.
+func ParseFlags[T Enumer](str string) (*Flags[T], error) {
+	f := NewFlags[T]()
+
+	if strings.TrimSpace(str) == "" {
+		return f, nil
+	}
+
+	for _, name := range strings.Split(str, "|") {
+		flag, err := enum_by_name[T](strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+
+		f.mask |= flag
+	}
+
+	return f, nil
+}
+
+// Value returns the union of the active flags.
+//
+// Returns:
+//   - T: The mask.
+func (f *Flags[T]) Value() T {
+	f.poison.Check("f")
+
+	return f.mask
+}
+
+// Set activates flags.
+//
+// Parameters:
+//   - flags: The flags.
+//
+// Returns:
+//   - *Flags[T]: The receiver. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If a flag is not in the registered value set.
+func (f *Flags[T]) Set(flags ...T) *Flags[T] {
+	pkg.Ensure(false, f)
+	pkg.ThrowIf(f.frozen, pkg.NewFrozen("f"))
+
+	f.mask |= flag_mask(flags)
+
+	return f
+}
+
+// Clear deactivates flags.
+//
+// Parameters:
+//   - flags: The flags.
+//
+// Returns:
+//   - *Flags[T]: The receiver. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If a flag is not in the registered value set.
+func (f *Flags[T]) Clear(flags ...T) *Flags[T] {
+	pkg.Ensure(false, f)
+	pkg.ThrowIf(f.frozen, pkg.NewFrozen("f"))
+
+	f.mask &^= flag_mask(flags)
+
+	return f
+}
+
+// Toggle flips flags.
+//
+// Parameters:
+//   - flags: The flags.
+//
+// Returns:
+//   - *Flags[T]: The receiver. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If a flag is not in the registered value set.
+func (f *Flags[T]) Toggle(flags ...T) *Flags[T] {
+	pkg.Ensure(false, f)
+	pkg.ThrowIf(f.frozen, pkg.NewFrozen("f"))
+
+	f.mask ^= flag_mask(flags)
+
+	return f
+}
+
+// Has checks whether a flag is active.
+//
+// Parameters:
+//   - flag: The flag.
+//
+// Returns:
+//   - bool: True if the flag is active, false otherwise.
+//
+// Throws:
+//   - *IllegalArgument: If the flag is not in the registered value set.
+func (f *Flags[T]) Has(flag T) bool {
+	pkg.Ensure(false, f)
+
+	mask := flag_mask([]T{flag})
+
+	return f.mask&mask == mask
+}
+
+// HasAll checks whether all the given flags are active.
+//
+// Parameters:
+//   - flags: The flags.
+//
+// Returns:
+//   - bool: True if every flag is active, or if none is given; false
+//     otherwise.
+//
+// Throws:
+//   - *IllegalArgument: If a flag is not in the registered value set.
+func (f *Flags[T]) HasAll(flags ...T) bool {
+	pkg.Ensure(false, f)
+
+	mask := flag_mask(flags)
+
+	return f.mask&mask == mask
+}
+
+// HasAny checks whether any of the given flags is active.
+//
+// Parameters:
+//   - flags: The flags.
+//
+// Returns:
+//   - bool: True if at least one flag is active, false otherwise.
+//
+// Throws:
+//   - *IllegalArgument: If a flag is not in the registered value set.
+func (f *Flags[T]) HasAny(flags ...T) bool {
+	pkg.Ensure(false, f)
+
+	return f.mask&flag_mask(flags) != 0
+}
+
+// Union activates the flags that are active in other.
+//
+// Parameters:
+//   - other: The other flag set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *Flags[T]: The receiver. Never returns nil.
+func (f *Flags[T]) Union(other *Flags[T]) *Flags[T] {
+	pkg.Ensure(false, f)
+	pkg.ThrowIf(f.frozen, pkg.NewFrozen("f"))
+
+	if other != nil {
+		f.mask |= other.mask
+	}
+
+	return f
+}
+
+// Intersection deactivates the flags that are not active in other.
+//
+// Parameters:
+//   - other: The other flag set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *Flags[T]: The receiver. Never returns nil.
+func (f *Flags[T]) Intersection(other *Flags[T]) *Flags[T] {
+	pkg.Ensure(false, f)
+	pkg.ThrowIf(f.frozen, pkg.NewFrozen("f"))
+
+	if other == nil {
+		f.mask = 0
+	} else {
+		f.mask &= other.mask
+	}
+
+	return f
+}
+
+// Complement flips every flag of the domain.
+//
+// Returns:
+//   - *Flags[T]: The receiver. Never returns nil.
+func (f *Flags[T]) Complement() *Flags[T] {
+	pkg.Ensure(false, f)
+	pkg.ThrowIf(f.frozen, pkg.NewFrozen("f"))
+
+	domain, _ := flag_domain[T]()
+	f.mask = domain &^ f.mask
+
+	return f
+}
+
+// IsEmpty checks whether no flag is active.
+//
+// Returns:
+//   - bool: True if no flag is active, false otherwise.
+func (f *Flags[T]) IsEmpty() bool {
+	f.poison.Check("f")
+
+	return f.mask == 0
+}
+
+// Size returns the number of active flags.
+//
+// Returns:
+//   - int: The number of active flags.
+func (f *Flags[T]) Size() int {
+	f.poison.Check("f")
+
+	return bits.OnesCount(uint(f.mask))
+}
+
+// Each returns an iterator over the active flags, in the order of the value
+// set.
+//
+// Returns:
+//   - iter.Seq[T]: The iterator. Never returns nil.
+func (f *Flags[T]) Each() iter.Seq[T] {
+	f.poison.Check("f")
+
+	_, values := flag_domain[T]()
+	mask := f.mask
+
+	fn := func(yield func(T) bool) {
+		for _, v := range values {
+			if mask&v == 0 {
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Hash implements the pkg.Hasher interface.
+func (f *Flags[T]) Hash() uint64 {
+	f.poison.Check("f")
+
+	return uint64(f.mask)
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (f *Flags[T]) Freeze() {
+	pkg.Ensure(false, f)
+
+	f.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (f *Flags[T]) IsFrozen() bool {
+	return f.frozen
+}
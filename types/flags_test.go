@@ -0,0 +1,76 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+type perm int
+
+const (
+	read perm = 1 << iota
+	write
+	exec
+)
+
+func (p perm) String() string {
+	switch p {
+	case read:
+		return "READ"
+	case write:
+		return "WRITE"
+	case exec:
+		return "EXEC"
+	default:
+		return "perm(?)"
+	}
+}
+
+func init() {
+	RegisterEnumValues(read, write, exec)
+}
+
+func TestFlags(t *testing.T) {
+	f := NewFlags(exec, read)
+
+	if f.String() != "READ|EXEC" || f.Size() != 2 {
+		t.Fatalf("unexpected flags: %s", f)
+	}
+
+	if !f.Has(read) || f.Has(write) || !f.HasAny(write, exec) || f.HasAll(read, write) {
+		t.Errorf("unexpected membership for %s", f)
+	}
+
+	f.Toggle(read, write).Clear(exec)
+	if f.String() != "WRITE" {
+		t.Errorf("expected WRITE, got %s", f)
+	}
+
+	if f.Complement().String() != "READ|EXEC" {
+		t.Errorf("expected the complement to be READ|EXEC, got %s", f)
+	}
+
+	if f.Intersection(NewFlags(exec, write)).String() != "EXEC" {
+		t.Errorf("expected EXEC, got %s", f)
+	}
+
+	if code := code_of(func() { f.Set(perm(8)) }); code != pkg.IllegalArgument {
+		t.Errorf("expected IllegalArgument, got %v", code)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	f, err := ParseFlags[perm]("EXEC | READ")
+	if err != nil || !f.Equals(NewFlags(read, exec)) {
+		t.Fatalf("unexpected flags: %v, %v", f, err)
+	}
+
+	if f, err := ParseFlags[perm](""); err != nil || !f.IsEmpty() {
+		t.Errorf("expected the empty set, got %v, %v", f, err)
+	}
+
+	if _, err := ParseFlags[perm]("READ|DELETE"); err == nil {
+		t.Errorf("expected an error for an unknown flag")
+	}
+}
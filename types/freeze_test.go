@@ -0,0 +1,83 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+)
+
+func expect_frozen(t *testing.T, name string, fn func()) {
+	t.Helper()
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, pkg.ErrFrozen) {
+			t.Errorf("%s: expected %v, got %v", name, pkg.ErrFrozen, err)
+		}
+	}()
+
+	fn()
+}
+
+func TestFreeze(t *testing.T) {
+	elem := NewInt().WithValue(1)
+
+	set := NewSet[*Int]().WithValue([]*Int{elem})
+	slice := slices.NewSlice[*Set[*Int]]().WithValue([]*Set[*Int]{set})
+
+	slice.Freeze()
+
+	if !set.IsFrozen() || !elem.IsFrozen() {
+		t.Fatalf("expected the elements to be frozen")
+	}
+
+	expect_frozen(t, "Slice.Append", func() { slice.Append(NewSet[*Int]()) })
+	expect_frozen(t, "Set.Add", func() { set.Add(NewInt()) })
+	expect_frozen(t, "Int.Set", func() { elem.Set(2) })
+	expect_frozen(t, "Int.WithValue", func() { elem.WithValue(2) })
+
+	thawed := pkg.DeepCopy(slice)
+	if thawed.IsFrozen() {
+		t.Fatalf("expected the copy to be thawed")
+	}
+
+	thawed.Append(NewSet[*Int]())
+
+	if thawed.Size() != 2 || slice.Size() != 1 {
+		t.Errorf("expected sizes 2 and 1, got %d and %d", thawed.Size(), slice.Size())
+	}
+}
+
+func TestFreezeClean(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	elem := NewInt().WithValue(1)
+
+	slice := slices.NewSlice[*Int]().WithValue([]*Int{elem})
+	slice.Freeze()
+
+	expect_frozen(t, "Slice.Clean", slice.Clean)
+	expect_frozen(t, "Int.Clean", elem.Clean)
+
+	if slice.Size() != 1 || elem.String() != "1" {
+		t.Fatalf("expected the frozen values to be intact, got %v", slice)
+	}
+
+	set := NewSet[*Int]().WithValue([]*Int{elem})
+	set.Clean()
+
+	key := NewInt().WithValue(2)
+
+	m := NewOrderedMap[*Int, *Int]()
+	m.Put(key, NewInt().WithValue(3))
+	m.Clean()
+
+	// Frozen elements are not cleaned along with their container.
+	if elem.String() != "1" || key.String() != "2" {
+		t.Errorf("expected the frozen elements to be intact, got %v and %v", elem, key)
+	}
+}
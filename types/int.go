@@ -1,8 +1,10 @@
 package types
 
 import (
+	"cmp"
 	"strconv"
 
+	"github.com/PlayerR9/GoSD/event"
 	"github.com/PlayerR9/GoSD/pkg"
 )
 
@@ -10,15 +12,29 @@ import (
 type Int struct {
 	// value is the index value.
 	value int
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
 }
 
 // String implements the fmt.Stringer interface.
 func (idx *Int) String() string {
+	idx.poison.Check("idx")
+
 	return strconv.Itoa(idx.value)
 }
 
 // DeepCopy implements the pkg.Type interface.
 func (idx *Int) DeepCopy() pkg.Type {
+	idx.poison.Check("idx")
+
 	return &Int{
 		value: idx.value,
 	}
@@ -27,10 +43,21 @@ func (idx *Int) DeepCopy() pkg.Type {
 // Ensure implements the pkg.Type interface.
 func (idx *Int) Ensure() {
 	pkg.ThrowIf(idx == nil, pkg.NewInvalidState("idx", pkg.NewNilValue()))
+
+	idx.poison.Check("idx")
 }
 
 // Clean implements the pkg.Type interface.
-func (idx *Int) Clean() {}
+func (idx *Int) Clean() {
+	if idx == nil {
+		return
+	}
+
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	idx.poison.Mark()
+	idx.observers = nil
+}
 
 // Equals implements the pkg.Type interface.
 //
@@ -71,8 +98,16 @@ func (idx *Int) WithValue(value int) *Int {
 		}
 	}
 
+	idx.poison.Check("idx")
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	old := idx.value
 	idx.value = value
 
+	if idx.observers != nil {
+		idx.observers.Notify(event.Set[int]{Index: -1, Old: old, New: value})
+	}
+
 	return idx
 }
 
@@ -81,15 +116,145 @@ func (idx *Int) WithValue(value int) *Int {
 // Returns:
 //   - int: The index value.
 func (idx Int) Value() int {
+	idx.poison.Check("idx")
+
 	return idx.value
 }
 
+// Reset sets the index value back to 0.
+func (idx *Int) Reset() {
+	if idx == nil {
+		return
+	}
+
+	idx.poison.Check("idx")
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	old := idx.value
+	idx.value = 0
+
+	if idx.observers != nil {
+		idx.observers.Notify(event.Set[int]{Index: -1, Old: old, New: 0})
+	}
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (idx *Int) Recycle(key *pkg.RecycleKey) {
+	idx.poison.Revive(key)
+	idx.Reset()
+}
+
 // Set sets the index value.
 //
 // Parameters:
 //   - value: The new value.
 func (idx *Int) Set(value int) {
 	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
 
+	old := idx.value
 	idx.value = value
+
+	if idx.observers != nil {
+		idx.observers.Notify(event.Set[int]{Index: -1, Old: old, New: value})
+	}
+}
+
+// AsNum returns a copy of the index as a Num[int], which provides checked
+// arithmetic.
+//
+// Returns:
+//   - *Num[int]: The copy. Never returns nil.
+func (idx *Int) AsNum() *Num[int] {
+	pkg.Ensure(false, idx)
+
+	return NewNum(idx.value)
+}
+
+// IntFromNum creates a new index from a Num[int].
+//
+// Parameters:
+//   - n: The number.
+//
+// Returns:
+//   - *Int: The new index. Never returns nil.
+//
+// Panics if n is nil.
+func IntFromNum(n *Num[int]) *Int {
+	pkg.Ensure(false, n)
+
+	return &Int{
+		value: n.value,
+	}
+}
+
+// Compare implements the pkg.Comparer interface.
+func (idx *Int) Compare(other *Int) int {
+	pkg.Ensure(false, idx)
+	pkg.Ensure(false, other)
+
+	return cmp.Compare(idx.value, other.value)
+}
+
+// Hash implements the pkg.Hasher interface.
+func (idx Int) Hash() uint64 {
+	idx.poison.Check("idx")
+
+	return uint64(idx.value)
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (idx *Int) Freeze() {
+	pkg.Ensure(false, idx)
+
+	idx.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (idx Int) IsFrozen() bool {
+	return idx.frozen
+}
+
+// Subscribe subscribes to the changes of the index.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Set[int] changes, synchronously after
+//     the mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (idx *Int) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, idx)
+
+	if idx.observers == nil {
+		idx.observers = &event.Observers{}
+	}
+
+	return idx.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the index into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (idx *Int) Batch(fn func()) {
+	pkg.Ensure(false, idx)
+
+	idx.observers.Batch(fn)
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Set[int].
+func (idx *Int) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, idx)
+
+	change, ok := c.(event.Set[int])
+	pkg.ThrowIf(!ok, event.NewNotRevertible(c, idx))
+
+	idx.Set(change.Old)
+
+	return event.Set[int]{Index: -1, Old: change.New, New: change.Old}
 }
@@ -0,0 +1,384 @@
+package types
+
+import (
+	"iter"
+	"sort"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// span is a non-empty range of an interval set.
+type span struct {
+	// lo is the first integer of the span.
+	lo int
+
+	// hi is the integer right after the last one of the span.
+	hi int
+}
+
+// IntervalSet is a set of integers stored as disjoint ranges. The ranges are
+// kept sorted and merged, so that two of them never overlap nor touch, and
+// point queries take O(log n) time in the number of ranges.
+type IntervalSet struct {
+	// spans are the ranges of the set, sorted, disjoint and not adjacent.
+	spans []span
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The set is written as its ranges, as in "IntervalSet[[1, 3), [5, 9)]".
+func (s *IntervalSet) String() string {
+	s.poison.Check("s")
+
+	var builder strings.Builder
+
+	builder.WriteString("IntervalSet[")
+
+	values := make([]string, 0, len(s.spans))
+	for r := range s.Ranges() {
+		values = append(values, r.String())
+	}
+	builder.WriteString(strings.Join(values, ", "))
+
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (s *IntervalSet) DeepCopy() pkg.Type {
+	if s == nil {
+		return nil
+	}
+
+	s.poison.Check("s")
+
+	return &IntervalSet{
+		spans: append([]span(nil), s.spans...),
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (s *IntervalSet) Ensure() {
+	pkg.ThrowIf(s == nil, pkg.NewInvalidState("s", pkg.NewNilValue()))
+
+	s.poison.Check("s")
+}
+
+// Clean implements the pkg.Type interface.
+func (s *IntervalSet) Clean() {
+	if s == nil {
+		return
+	}
+
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	s.spans = nil
+
+	s.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two interval sets are equal if they have the same integers. As the ranges
+// are normalized, this is the case if they have the same ranges.
+func (s *IntervalSet) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, s)
+	pkg.Ensure(false, other)
+
+	other_val, ok := other.(*IntervalSet)
+	if !ok {
+		return false
+	}
+
+	if len(s.spans) != len(other_val.spans) {
+		return false
+	}
+
+	for i, sp := range s.spans {
+		if sp != other_val.spans[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewIntervalSet creates a new interval set.
+//
+// Parameters:
+//   - ranges: The initial ranges. They may overlap; nil ranges are ignored.
+//
+// Returns:
+//   - *IntervalSet: The new interval set. Never returns nil.
+func NewIntervalSet(ranges ...*Range) *IntervalSet {
+	s := &IntervalSet{}
+
+	for _, r := range ranges {
+		if r != nil {
+			s.Add(r)
+		}
+	}
+
+	return s
+}
+
+// add adds the integers in [lo, hi), merging the ranges it overlaps or
+// touches.
+//
+// Parameters:
+//   - lo: The first integer.
+//   - hi: The integer right after the last one.
+func (s *IntervalSet) add(lo, hi int) {
+	if lo >= hi {
+		return
+	}
+
+	i := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].hi >= lo })
+	j := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].lo > hi })
+
+	if i < j {
+		lo = min(lo, s.spans[i].lo)
+		hi = max(hi, s.spans[j-1].hi)
+	}
+
+	s.replace(i, j, span{lo: lo, hi: hi})
+}
+
+// remove removes the integers in [lo, hi), splitting the range that contains
+// it if needed.
+//
+// Parameters:
+//   - lo: The first integer.
+//   - hi: The integer right after the last one.
+func (s *IntervalSet) remove(lo, hi int) {
+	if lo >= hi {
+		return
+	}
+
+	i := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].hi > lo })
+	j := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].lo >= hi })
+
+	if i >= j {
+		return
+	}
+
+	var kept []span
+
+	if first := s.spans[i]; first.lo < lo {
+		kept = append(kept, span{lo: first.lo, hi: lo})
+	}
+
+	if last := s.spans[j-1]; last.hi > hi {
+		kept = append(kept, span{lo: hi, hi: last.hi})
+	}
+
+	s.replace(i, j, kept...)
+}
+
+// replace replaces the spans in [i, j) with others.
+//
+// Parameters:
+//   - i: The first position.
+//   - j: The position right after the last one.
+//   - spans: The new spans.
+func (s *IntervalSet) replace(i, j int, spans ...span) {
+	tail := append(spans, s.spans[j:]...)
+	s.spans = append(s.spans[:i], tail...)
+}
+
+// Add adds the integers of a range.
+//
+// Parameters:
+//   - r: The range.
+//
+// Panics if r is nil.
+func (s *IntervalSet) Add(r *Range) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+	pkg.Ensure(false, r)
+
+	s.add(r.lo, r.hi)
+}
+
+// Remove removes the integers of a range.
+//
+// Parameters:
+//   - r: The range.
+//
+// Panics if r is nil.
+func (s *IntervalSet) Remove(r *Range) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+	pkg.Ensure(false, r)
+
+	s.remove(r.lo, r.hi)
+}
+
+// Union adds the integers of other.
+//
+// Parameters:
+//   - other: The other interval set. If nil, it is treated as empty.
+func (s *IntervalSet) Union(other *IntervalSet) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	if other == nil {
+		return
+	}
+
+	// Copy in case other is s.
+	for _, sp := range append([]span(nil), other.spans...) {
+		s.add(sp.lo, sp.hi)
+	}
+}
+
+// Difference removes the integers of other.
+//
+// Parameters:
+//   - other: The other interval set. If nil, it is treated as empty.
+func (s *IntervalSet) Difference(other *IntervalSet) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	if other == nil {
+		return
+	}
+
+	for _, sp := range append([]span(nil), other.spans...) {
+		s.remove(sp.lo, sp.hi)
+	}
+}
+
+// Contains checks whether an integer is in the set, in O(log n) time.
+//
+// Parameters:
+//   - x: The integer.
+//
+// Returns:
+//   - bool: True if the integer is in the set, false otherwise.
+func (s *IntervalSet) Contains(x int) bool {
+	_, ok := s.Find(x)
+	return ok
+}
+
+// Find returns the range of the set that contains an integer, in O(log n)
+// time.
+//
+// Parameters:
+//   - x: The integer.
+//
+// Returns:
+//   - *Range: The range. Nil if the integer is not in the set.
+//   - bool: True if the integer is in the set, false otherwise.
+func (s *IntervalSet) Find(x int) (*Range, bool) {
+	pkg.Ensure(false, s)
+
+	i := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].hi > x })
+	if i == len(s.spans) || s.spans[i].lo > x {
+		return nil, false
+	}
+
+	return &Range{lo: s.spans[i].lo, hi: s.spans[i].hi}, true
+}
+
+// Len returns the number of integers in the set.
+//
+// Returns:
+//   - int: The number of integers.
+func (s *IntervalSet) Len() int {
+	s.poison.Check("s")
+
+	var count int
+
+	for _, sp := range s.spans {
+		count += sp.hi - sp.lo
+	}
+
+	return count
+}
+
+// IsEmpty checks whether the set is empty.
+//
+// Returns:
+//   - bool: True if the set is empty, false otherwise.
+func (s *IntervalSet) IsEmpty() bool {
+	s.poison.Check("s")
+
+	return len(s.spans) == 0
+}
+
+// Reset removes all integers from the set.
+func (s *IntervalSet) Reset() {
+	if s == nil {
+		return
+	}
+
+	s.poison.Check("s")
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	s.spans = s.spans[:0]
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (s *IntervalSet) Recycle(key *pkg.RecycleKey) {
+	s.poison.Revive(key)
+	s.Reset()
+}
+
+// Ranges returns an iterator over the ranges of the set, in increasing order.
+//
+// Returns:
+//   - iter.Seq[*Range]: The iterator. Never returns nil.
+func (s *IntervalSet) Ranges() iter.Seq[*Range] {
+	s.poison.Check("s")
+
+	fn := func(yield func(*Range) bool) {
+		for _, sp := range s.spans {
+			if !yield(&Range{lo: sp.lo, hi: sp.hi}) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Each returns an iterator over the integers of the set, in increasing order.
+//
+// Returns:
+//   - iter.Seq[int]: The iterator. Never returns nil.
+func (s *IntervalSet) Each() iter.Seq[int] {
+	s.poison.Check("s")
+
+	fn := func(yield func(int) bool) {
+		for _, sp := range s.spans {
+			for x := sp.lo; x < sp.hi; x++ {
+				if !yield(x) {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (s *IntervalSet) Freeze() {
+	pkg.Ensure(false, s)
+
+	s.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (s *IntervalSet) IsFrozen() bool {
+	return s.frozen
+}
@@ -0,0 +1,475 @@
+package types
+
+import (
+	"iter"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// entry is a key-value pair of a map.
+type entry[K, V pkg.Type] struct {
+	// key is the key.
+	key K
+
+	// value is the value.
+	value V
+
+	// hash is the hash of the key. Only meaningful if hashed is true.
+	hash uint64
+
+	// hashed is whether the key implements pkg.Hasher.
+	hashed bool
+}
+
+// Map is a map whose keys are compared with Equals. The keys that implement
+// pkg.Hasher are found through their hash; the others are found by comparing
+// them to every key.
+//
+// The keys are frozen when they are put in the map, if they implement
+// pkg.Freezer, since mutating a key would break the lookups.
+type Map[K, V pkg.Type] struct {
+	// entries are the entries of the map.
+	entries []entry[K, V]
+
+	// index maps the hashes of the hashed keys to the positions of their
+	// entries.
+	index map[uint64][]int
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+func (m *Map[K, V]) String() string {
+	m.poison.Check("m")
+
+	var builder strings.Builder
+
+	builder.WriteString("Map[")
+
+	values := make([]string, 0, len(m.entries))
+	for _, e := range m.entries {
+		values = append(values, e.key.String()+": "+e.value.String())
+	}
+	builder.WriteString(strings.Join(values, ", "))
+
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+//
+// Both the keys and the values are copied.
+func (m *Map[K, V]) DeepCopy() pkg.Type {
+	if m == nil {
+		return nil
+	}
+
+	m.poison.Check("m")
+
+	m_copy := NewMap[K, V]()
+
+	for _, e := range m.entries {
+		m_copy.Put(pkg.DeepCopy(e.key), pkg.DeepCopy(e.value))
+	}
+
+	return m_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (m *Map[K, V]) Ensure() {
+	pkg.ThrowIf(m == nil, pkg.NewInvalidState("m", pkg.NewNilValue()))
+
+	m.poison.Check("m")
+}
+
+// Clean implements the pkg.Type interface.
+//
+// Both the keys and the values are cleaned.
+func (m *Map[K, V]) Clean() {
+	if m == nil {
+		return
+	}
+
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	for _, e := range m.entries {
+		pkg.Clean(e.key)
+		pkg.Clean(e.value)
+	}
+
+	m.entries = nil
+	m.index = nil
+
+	m.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two maps are equal if they have equal values for the same keys, regardless
+// of the order of their entries.
+func (m *Map[K, V]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, other)
+
+	other_val, ok := other.(*Map[K, V])
+	if !ok {
+		return false
+	}
+
+	if len(m.entries) != len(other_val.entries) {
+		return false
+	}
+
+	for _, e := range m.entries {
+		i := other_val.find(e.key)
+		if i < 0 || !e.value.Equals(other_val.entries[i].value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewMap creates a new empty map.
+//
+// Returns:
+//   - *Map[K, V]: The new map. Never returns nil.
+func NewMap[K, V pkg.Type]() *Map[K, V] {
+	return &Map[K, V]{
+		index: make(map[uint64][]int),
+	}
+}
+
+// find returns the position of the entry of a key.
+//
+// Parameters:
+//   - key: The key. It must not be nil.
+//
+// Returns:
+//   - int: The position, or -1 if the key is not in the map.
+func (m *Map[K, V]) find(key K) int {
+	return find_entry(m.entries, m.index, key)
+}
+
+// find_entry returns the position of the entry of a key.
+//
+// Parameters:
+//   - entries: The entries.
+//   - index: The positions of the hashed keys, by hash.
+//   - key: The key. It must not be nil.
+//
+// Returns:
+//   - int: The position, or -1 if the key is not in the entries.
+func find_entry[K, V pkg.Type](entries []entry[K, V], index map[uint64][]int, key K) int {
+	if h, ok := any(key).(pkg.Hasher); ok {
+		for _, i := range index[h.Hash()] {
+			if entries[i].key.Equals(key) {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	for i, e := range entries {
+		if e.key.Equals(key) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// new_entry creates the entry of a key that is not in a map yet. The key is
+// frozen.
+//
+// Parameters:
+//   - key: The key. It must not be nil.
+//   - value: The value.
+//
+// Returns:
+//   - entry[K, V]: The new entry.
+func new_entry[K, V pkg.Type](key K, value V) entry[K, V] {
+	pkg.Freeze(key)
+
+	e := entry[K, V]{
+		key:   key,
+		value: value,
+	}
+
+	if h, ok := any(key).(pkg.Hasher); ok {
+		e.hash = h.Hash()
+		e.hashed = true
+	}
+
+	return e
+}
+
+// Put associates a value with a key. If the key is already in the map, its
+// value is replaced and the key in the map is kept.
+//
+// Parameters:
+//   - key: The key.
+//   - value: The value.
+//
+// Returns:
+//   - V: The previous value, which is not cleaned. The zero value if there was
+//     none.
+//   - bool: True if a previous value was replaced, false otherwise.
+//
+// Panics if key is nil.
+func (m *Map[K, V]) Put(key K, value V) (V, bool) {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+	pkg.Ensure(false, key)
+
+	if i := m.find(key); i >= 0 {
+		old := m.entries[i].value
+		m.entries[i].value = value
+
+		return old, true
+	}
+
+	e := new_entry(key, value)
+
+	if e.hashed {
+		if m.index == nil {
+			m.index = make(map[uint64][]int)
+		}
+
+		m.index[e.hash] = append(m.index[e.hash], len(m.entries))
+	}
+
+	m.entries = append(m.entries, e)
+
+	return *new(V), false
+}
+
+// Get returns the value associated with a key.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - V: The value. The zero value if the key is not in the map.
+//   - bool: True if the key is in the map, false otherwise.
+//
+// Panics if key is nil.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, key)
+
+	i := m.find(key)
+	if i < 0 {
+		return *new(V), false
+	}
+
+	return m.entries[i].value, true
+}
+
+// Has checks whether a key is in the map.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - bool: True if the key is in the map, false otherwise.
+//
+// Panics if key is nil.
+func (m *Map[K, V]) Has(key K) bool {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, key)
+
+	return m.find(key) >= 0
+}
+
+// Delete removes a key and its value from the map. Neither of them is
+// cleaned. The last entry takes the place of the removed one.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - V: The removed value. The zero value if the key was not in the map.
+//   - bool: True if the key was in the map, false otherwise.
+//
+// Panics if key is nil.
+func (m *Map[K, V]) Delete(key K) (V, bool) {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+	pkg.Ensure(false, key)
+
+	i := m.find(key)
+	if i < 0 {
+		return *new(V), false
+	}
+
+	removed := m.entries[i]
+	last := len(m.entries) - 1
+
+	if removed.hashed {
+		m.unindex(removed.hash, i)
+	}
+
+	if i != last {
+		moved := m.entries[last]
+		m.entries[i] = moved
+
+		if moved.hashed {
+			m.unindex(moved.hash, last)
+			m.index[moved.hash] = append(m.index[moved.hash], i)
+		}
+	}
+
+	m.entries[last] = entry[K, V]{}
+	m.entries = m.entries[:last]
+
+	return removed.value, true
+}
+
+// unindex removes a position from the index.
+//
+// Parameters:
+//   - hash: The hash of the key at the position.
+//   - i: The position.
+func (m *Map[K, V]) unindex(hash uint64, i int) {
+	positions := m.index[hash]
+
+	for j, pos := range positions {
+		if pos != i {
+			continue
+		}
+
+		positions = append(positions[:j], positions[j+1:]...)
+		break
+	}
+
+	if len(positions) == 0 {
+		delete(m.index, hash)
+	} else {
+		m.index[hash] = positions
+	}
+}
+
+// IsEmpty checks whether the map is empty.
+//
+// Returns:
+//   - bool: True if the map is empty, false otherwise.
+func (m *Map[K, V]) IsEmpty() bool {
+	m.poison.Check("m")
+
+	return len(m.entries) == 0
+}
+
+// Size returns the number of keys in the map.
+//
+// Returns:
+//   - int: The number of keys in the map.
+func (m *Map[K, V]) Size() int {
+	m.poison.Check("m")
+
+	return len(m.entries)
+}
+
+// Reset removes all entries from the map without cleaning them.
+func (m *Map[K, V]) Reset() {
+	if m == nil {
+		return
+	}
+
+	m.poison.Check("m")
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	clear(m.entries)
+	m.entries = m.entries[:0]
+	m.index = make(map[uint64][]int)
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (m *Map[K, V]) Recycle(key *pkg.RecycleKey) {
+	m.poison.Revive(key)
+	m.Reset()
+}
+
+// Keys returns an iterator over the keys of the map.
+//
+// Returns:
+//   - iter.Seq[K]: The iterator. Never returns nil.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	m.poison.Check("m")
+
+	fn := func(yield func(K) bool) {
+		for _, e := range m.entries {
+			if !yield(e.key) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Values returns an iterator over the values of the map.
+//
+// Returns:
+//   - iter.Seq[V]: The iterator. Never returns nil.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	m.poison.Check("m")
+
+	fn := func(yield func(V) bool) {
+		for _, e := range m.entries {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// All returns an iterator over the entries of the map.
+//
+// Returns:
+//   - iter.Seq2[K, V]: The iterator. Never returns nil.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	m.poison.Check("m")
+
+	fn := func(yield func(K, V) bool) {
+		for _, e := range m.entries {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Freeze implements the pkg.Freezer interface.
+//
+// The values that implement pkg.Freezer are frozen as well; the keys already
+// are.
+func (m *Map[K, V]) Freeze() {
+	pkg.Ensure(false, m)
+
+	if m.frozen {
+		return
+	}
+
+	m.frozen = true
+
+	for _, e := range m.entries {
+		pkg.Freeze(e.value)
+	}
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (m *Map[K, V]) IsFrozen() bool {
+	return m.frozen
+}
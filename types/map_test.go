@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	m := NewMap[*Int, *String]()
+
+	for i := 0; i < 5; i++ {
+		m.Put(NewInt().WithValue(i), NewString("v"))
+	}
+
+	old, ok := m.Put(NewInt().WithValue(2), NewString("two"))
+	if !ok || old.Value() != "v" {
+		t.Errorf("expected to replace v, got %v, %t", old, ok)
+	}
+
+	m.Delete(NewInt().WithValue(0))
+
+	if m.Size() != 4 || m.Has(NewInt().WithValue(0)) {
+		t.Fatalf("expected 4 keys without 0, got %s", m)
+	}
+
+	for k, v := range m.All() {
+		got, ok := m.Get(k)
+		if !ok || got != v {
+			t.Errorf("expected %s to map to %s after a delete", k, v)
+		}
+	}
+
+	if value, _ := m.Get(NewInt().WithValue(2)); value.Value() != "two" {
+		t.Errorf("expected two, got %s", value)
+	}
+}
+
+func TestMapScannedKeys(t *testing.T) {
+	m := NewMap[*Set[*Int], *Int]()
+
+	key := NewSet[*Int]().WithValue([]*Int{NewInt().WithValue(1)})
+	m.Put(key, NewInt().WithValue(1))
+
+	if !key.IsFrozen() {
+		t.Errorf("expected the key to be frozen")
+	}
+
+	lookup := NewSet[*Int]().WithValue([]*Int{NewInt().WithValue(1)})
+	if !m.Has(lookup) {
+		t.Errorf("expected %s to be found by scanning", lookup)
+	}
+}
+
+func TestMapEquals(t *testing.T) {
+	a := NewMap[*Int, *Int]()
+	b := NewMap[*Int, *Int]()
+
+	for i := 0; i < 3; i++ {
+		a.Put(NewInt().WithValue(i), NewInt().WithValue(i*i))
+		b.Put(NewInt().WithValue(2-i), NewInt().WithValue((2-i)*(2-i)))
+	}
+
+	if !a.Equals(b) {
+		t.Errorf("expected %s to equal %s", a, b)
+	}
+
+	c := a.DeepCopy().(*Map[*Int, *Int])
+
+	value, _ := a.Get(NewInt().WithValue(1))
+	value.Set(7)
+
+	if a.Equals(c) {
+		t.Errorf("expected the copy not to share the values")
+	}
+}
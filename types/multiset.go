@@ -0,0 +1,536 @@
+package types
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// bag_entry is a distinct element of a multiset with its multiplicity.
+type bag_entry[T pkg.Type] struct {
+	// elem is the element.
+	elem T
+
+	// count is the multiplicity of the element. Always positive.
+	count int
+
+	// hash is the hash of the element. Only meaningful if hashed is true.
+	hash uint64
+
+	// hashed is whether the element implements pkg.Hasher.
+	hashed bool
+}
+
+// MultiSet is a set that counts how many times each element occurs. The
+// elements are compared with Equals and, as in Map, the ones that implement
+// pkg.Hasher are found through their hash.
+//
+// Only the first occurrence of an element is stored; the following ones only
+// increase its count. The stored elements are frozen, if they implement
+// pkg.Freezer, since mutating them would break the lookups.
+type MultiSet[T pkg.Type] struct {
+	// entries are the distinct elements, in the order they were first added.
+	entries []bag_entry[T]
+
+	// index maps the hashes of the hashed elements to the positions of their
+	// entries.
+	index map[uint64][]int
+
+	// size is the number of elements, with multiplicity.
+	size int
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The elements are written with their count, as in "MultiSet[a: 2, b: 1]".
+func (m *MultiSet[T]) String() string {
+	m.poison.Check("m")
+
+	var builder strings.Builder
+
+	builder.WriteString("MultiSet[")
+
+	values := make([]string, 0, len(m.entries))
+	for _, e := range m.entries {
+		values = append(values, e.elem.String()+": "+strconv.Itoa(e.count))
+	}
+	builder.WriteString(strings.Join(values, ", "))
+
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (m *MultiSet[T]) DeepCopy() pkg.Type {
+	if m == nil {
+		return nil
+	}
+
+	m.poison.Check("m")
+
+	m_copy := NewMultiSet[T]()
+
+	for _, e := range m.entries {
+		m_copy.Add(pkg.DeepCopy(e.elem), e.count)
+	}
+
+	return m_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (m *MultiSet[T]) Ensure() {
+	pkg.ThrowIf(m == nil, pkg.NewInvalidState("m", pkg.NewNilValue()))
+
+	m.poison.Check("m")
+}
+
+// Clean implements the pkg.Type interface.
+//
+// The stored elements are cleaned.
+func (m *MultiSet[T]) Clean() {
+	if m == nil {
+		return
+	}
+
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	for _, e := range m.entries {
+		pkg.Clean(e.elem)
+	}
+
+	m.entries = nil
+	m.index = nil
+	m.size = 0
+
+	m.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two multisets are equal if every element has the same count in both,
+// regardless of the order of the elements.
+func (m *MultiSet[T]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, other)
+
+	other_val, ok := other.(*MultiSet[T])
+	if !ok {
+		return false
+	}
+
+	if len(m.entries) != len(other_val.entries) || m.size != other_val.size {
+		return false
+	}
+
+	for _, e := range m.entries {
+		if other_val.Count(e.elem) != e.count {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewMultiSet creates a new empty multiset.
+//
+// Returns:
+//   - *MultiSet[T]: The new multiset. Never returns nil.
+func NewMultiSet[T pkg.Type]() *MultiSet[T] {
+	return &MultiSet[T]{
+		index: make(map[uint64][]int),
+	}
+}
+
+// find returns the position of the entry of an element.
+//
+// Parameters:
+//   - elem: The element. It must not be nil.
+//
+// Returns:
+//   - int: The position, or -1 if the element is not in the multiset.
+func (m *MultiSet[T]) find(elem T) int {
+	if h, ok := any(elem).(pkg.Hasher); ok {
+		for _, i := range m.index[h.Hash()] {
+			if m.entries[i].elem.Equals(elem) {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	for i, e := range m.entries {
+		if e.elem.Equals(elem) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// reindex rebuilds the index after the entries moved.
+func (m *MultiSet[T]) reindex() {
+	m.index = make(map[uint64][]int)
+
+	for i, e := range m.entries {
+		if e.hashed {
+			m.index[e.hash] = append(m.index[e.hash], i)
+		}
+	}
+}
+
+// Add adds n occurrences of an element.
+//
+// Parameters:
+//   - elem: The element.
+//   - n: The number of occurrences to add.
+//
+// Returns:
+//   - int: The count of the element after the addition.
+//
+// Throws:
+//   - *IllegalArgument: If n is negative.
+//
+// Panics if elem is nil.
+func (m *MultiSet[T]) Add(elem T, n int) int {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+	pkg.Ensure(false, elem)
+	pkg.ThrowIf(n < 0, pkg.NewIllegalArgument(errors.New("count must not be negative")))
+
+	if i := m.find(elem); i >= 0 {
+		m.entries[i].count += n
+		m.size += n
+
+		return m.entries[i].count
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	pkg.Freeze(elem)
+
+	e := bag_entry[T]{
+		elem:  elem,
+		count: n,
+	}
+
+	if h, ok := any(elem).(pkg.Hasher); ok {
+		e.hash = h.Hash()
+		e.hashed = true
+
+		if m.index == nil {
+			m.index = make(map[uint64][]int)
+		}
+
+		m.index[e.hash] = append(m.index[e.hash], len(m.entries))
+	}
+
+	m.entries = append(m.entries, e)
+	m.size += n
+
+	return n
+}
+
+// Count returns the number of occurrences of an element.
+//
+// Parameters:
+//   - elem: The element.
+//
+// Returns:
+//   - int: The count. Zero if the element is not in the multiset.
+//
+// Panics if elem is nil.
+func (m *MultiSet[T]) Count(elem T) int {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, elem)
+
+	i := m.find(elem)
+	if i < 0 {
+		return 0
+	}
+
+	return m.entries[i].count
+}
+
+// Remove removes up to n occurrences of an element. When its count reaches
+// zero, the stored element is dropped without being cleaned.
+//
+// Parameters:
+//   - elem: The element.
+//   - n: The number of occurrences to remove.
+//
+// Returns:
+//   - int: The number of occurrences actually removed.
+//
+// Throws:
+//   - *IllegalArgument: If n is negative.
+//
+// Panics if elem is nil.
+func (m *MultiSet[T]) Remove(elem T, n int) int {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+	pkg.Ensure(false, elem)
+	pkg.ThrowIf(n < 0, pkg.NewIllegalArgument(errors.New("count must not be negative")))
+
+	i := m.find(elem)
+	if i < 0 {
+		return 0
+	}
+
+	return m.decrease(i, n)
+}
+
+// decrease lowers the count of an entry, dropping it when the count reaches
+// zero.
+//
+// Parameters:
+//   - i: The position of the entry.
+//   - n: The number of occurrences to remove. It must not be negative.
+//
+// Returns:
+//   - int: The number of occurrences actually removed.
+func (m *MultiSet[T]) decrease(i, n int) int {
+	e := &m.entries[i]
+
+	if n < e.count {
+		e.count -= n
+		m.size -= n
+
+		return n
+	}
+
+	removed := e.count
+	m.size -= removed
+
+	copy(m.entries[i:], m.entries[i+1:])
+	m.entries[len(m.entries)-1] = bag_entry[T]{}
+	m.entries = m.entries[:len(m.entries)-1]
+
+	m.reindex()
+
+	return removed
+}
+
+// Distinct returns a new set with deep copies of the distinct elements.
+//
+// Returns:
+//   - *Set[T]: The new set. Never returns nil.
+func (m *MultiSet[T]) Distinct() *Set[T] {
+	pkg.Ensure(false, m)
+
+	s := NewSet[T]()
+
+	for _, e := range m.entries {
+		s.values = append(s.values, pkg.DeepCopy(e.elem))
+	}
+
+	return s
+}
+
+// MostCommon returns an iterator over the k most common elements with their
+// counts, from the most common one. Elements with the same count come in the
+// order they were first added.
+//
+// Parameters:
+//   - k: The number of elements. If negative, all of them are yielded.
+//
+// Returns:
+//   - iter.Seq2[T, int]: The iterator. Never returns nil.
+func (m *MultiSet[T]) MostCommon(k int) iter.Seq2[T, int] {
+	pkg.Ensure(false, m)
+
+	sorted := slices.Clone(m.entries)
+	slices.SortStableFunc(sorted, func(a, b bag_entry[T]) int {
+		return cmp.Compare(b.count, a.count)
+	})
+
+	if k >= 0 && k < len(sorted) {
+		sorted = sorted[:k]
+	}
+
+	fn := func(yield func(T, int) bool) {
+		for _, e := range sorted {
+			if !yield(e.elem, e.count) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Union raises the count of every element to its count in other, if higher.
+// As with Set.Union, the elements added from other are shared with it.
+//
+// Parameters:
+//   - other: The other multiset. If nil, it is treated as empty.
+func (m *MultiSet[T]) Union(other *MultiSet[T]) {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	if other == nil || other == m {
+		return
+	}
+
+	for _, e := range other.entries {
+		if count := m.Count(e.elem); count < e.count {
+			m.Add(e.elem, e.count-count)
+		}
+	}
+}
+
+// Intersection lowers the count of every element to its count in other, if
+// lower. The dropped elements are not cleaned.
+//
+// Parameters:
+//   - other: The other multiset. If nil, it is treated as empty.
+func (m *MultiSet[T]) Intersection(other *MultiSet[T]) {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	if other == m {
+		return
+	}
+
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		var count int
+
+		if other != nil {
+			count = other.Count(m.entries[i].elem)
+		}
+
+		if excess := m.entries[i].count - count; excess > 0 {
+			m.decrease(i, excess)
+		}
+	}
+}
+
+// Sum adds the counts of other to the ones of the multiset. As with
+// Set.Union, the elements added from other are shared with it.
+//
+// Parameters:
+//   - other: The other multiset. If nil, it is treated as empty.
+func (m *MultiSet[T]) Sum(other *MultiSet[T]) {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	if other == nil {
+		return
+	}
+
+	// Copy in case other is m.
+	entries := slices.Clone(other.entries)
+
+	for _, e := range entries {
+		m.Add(e.elem, e.count)
+	}
+}
+
+// IsEmpty checks whether the multiset is empty.
+//
+// Returns:
+//   - bool: True if the multiset is empty, false otherwise.
+func (m *MultiSet[T]) IsEmpty() bool {
+	m.poison.Check("m")
+
+	return m.size == 0
+}
+
+// Size returns the number of elements in the multiset, with multiplicity.
+//
+// Returns:
+//   - int: The number of elements.
+func (m *MultiSet[T]) Size() int {
+	m.poison.Check("m")
+
+	return m.size
+}
+
+// Reset removes all elements from the multiset without cleaning them.
+func (m *MultiSet[T]) Reset() {
+	if m == nil {
+		return
+	}
+
+	m.poison.Check("m")
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	clear(m.entries)
+	m.entries = m.entries[:0]
+	m.index = make(map[uint64][]int)
+	m.size = 0
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (m *MultiSet[T]) Recycle(key *pkg.RecycleKey) {
+	m.poison.Revive(key)
+	m.Reset()
+}
+
+// Each returns an iterator over the elements of the multiset, with
+// multiplicity: an element with count n is yielded n times in a row.
+//
+// Returns:
+//   - iter.Seq[T]: The iterator. Never returns nil.
+func (m *MultiSet[T]) Each() iter.Seq[T] {
+	m.poison.Check("m")
+
+	fn := func(yield func(T) bool) {
+		for _, e := range m.entries {
+			for range e.count {
+				if !yield(e.elem) {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// All returns an iterator over the distinct elements of the multiset with
+// their counts.
+//
+// Returns:
+//   - iter.Seq2[T, int]: The iterator. Never returns nil.
+func (m *MultiSet[T]) All() iter.Seq2[T, int] {
+	m.poison.Check("m")
+
+	fn := func(yield func(T, int) bool) {
+		for _, e := range m.entries {
+			if !yield(e.elem, e.count) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Freeze implements the pkg.Freezer interface.
+//
+// The stored elements already are frozen.
+func (m *MultiSet[T]) Freeze() {
+	pkg.Ensure(false, m)
+
+	m.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (m *MultiSet[T]) IsFrozen() bool {
+	return m.frozen
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+)
+
+// bag creates a multiset of strings, one occurrence per name.
+func bag(names ...string) *MultiSet[*String] {
+	m := NewMultiSet[*String]()
+
+	for _, name := range names {
+		m.Add(NewString(name), 1)
+	}
+
+	return m
+}
+
+func TestMultiSet(t *testing.T) {
+	m := bag("a", "b", "a", "c", "a", "b")
+
+	if m.Size() != 6 || m.Count(NewString("a")) != 3 || m.Count(NewString("z")) != 0 {
+		t.Fatalf("unexpected counts: %s", m)
+	}
+
+	if n := m.Remove(NewString("b"), 5); n != 2 || m.Count(NewString("b")) != 0 {
+		t.Errorf("expected to remove both b, got %d: %s", n, m)
+	}
+
+	if m.String() != "MultiSet[a: 3, c: 1]" {
+		t.Errorf("unexpected multiset: %s", m)
+	}
+
+	if !m.Distinct().Equals(NewSet[*String]().WithValue([]*String{NewString("c"), NewString("a")})) {
+		t.Errorf("unexpected distinct elements: %s", m.Distinct())
+	}
+
+	var count int
+	for range m.Each() {
+		count++
+	}
+
+	if count != 4 {
+		t.Errorf("expected 4 elements with multiplicity, got %d", count)
+	}
+}
+
+func TestMultiSetMostCommon(t *testing.T) {
+	m := bag("x", "y", "y", "z", "z", "w")
+
+	var names []string
+	for elem := range m.MostCommon(3) {
+		names = append(names, elem.Value())
+	}
+
+	if len(names) != 3 || names[0] != "y" || names[1] != "z" || names[2] != "x" {
+		t.Errorf("unexpected most common elements: %v", names)
+	}
+}
+
+func TestMultiSetAlgebra(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(a, b *MultiSet[*String])
+		want *MultiSet[*String]
+	}{
+		{"union", (*MultiSet[*String]).Union, bag("a", "a", "b", "c", "c")},
+		{"intersection", (*MultiSet[*String]).Intersection, bag("a", "c")},
+		{"sum", (*MultiSet[*String]).Sum, bag("a", "a", "a", "b", "c", "c", "c")},
+	}
+
+	for _, tt := range tests {
+		a := bag("a", "a", "b", "c")
+		tt.op(a, bag("a", "c", "c"))
+
+		if !a.Equals(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, a)
+		}
+	}
+
+	if bag("a", "b").Equals(bag("a", "b", "b")) {
+		t.Errorf("expected different counts to differ")
+	}
+}
@@ -0,0 +1,549 @@
+package types
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+
+	"github.com/PlayerR9/GoSD/event"
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Signed is the set of signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is the set of unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is the set of integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is the set of floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the set of numeric types Num accepts.
+type Number interface {
+	Integer | Float
+}
+
+// Num is a number. Its arithmetic methods update the number in place and
+// throw instead of wrapping around on overflow.
+type Num[T Number] struct {
+	// value is the number value.
+	value T
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
+}
+
+// String implements the fmt.Stringer interface.
+func (n *Num[T]) String() string {
+	n.poison.Check("n")
+
+	return fmt.Sprint(n.value)
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (n *Num[T]) DeepCopy() pkg.Type {
+	n.poison.Check("n")
+
+	return &Num[T]{
+		value: n.value,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (n *Num[T]) Ensure() {
+	pkg.ThrowIf(n == nil, pkg.NewInvalidState("n", pkg.NewNilValue()))
+
+	n.poison.Check("n")
+}
+
+// Clean implements the pkg.Type interface.
+func (n *Num[T]) Clean() {
+	if n == nil {
+		return
+	}
+
+	pkg.ThrowIf(n.frozen, pkg.NewFrozen("n"))
+
+	n.poison.Mark()
+	n.observers = nil
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two numbers are equal if they have the same value and the same type. As
+// for float64, NaN is not equal to itself.
+func (n *Num[T]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, n)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Num[T]:
+		return n.value == other.value
+	default:
+		return false
+	}
+}
+
+// NewNum creates a new number.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *Num[T]: The new number. Never returns nil.
+func NewNum[T Number](value T) *Num[T] {
+	return &Num[T]{
+		value: value,
+	}
+}
+
+// WithValue sets the value of the number.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *Num[T]: The number. If the receiver is nil, a new one is created. Never
+//     returns nil.
+func (n *Num[T]) WithValue(value T) *Num[T] {
+	if n == nil {
+		return &Num[T]{
+			value: value,
+		}
+	}
+
+	n.Set(value)
+
+	return n
+}
+
+// Value returns the number value.
+//
+// Returns:
+//   - T: The number value.
+func (n Num[T]) Value() T {
+	n.poison.Check("n")
+
+	return n.value
+}
+
+// Reset sets the number value back to 0.
+func (n *Num[T]) Reset() {
+	if n == nil {
+		return
+	}
+
+	n.poison.Check("n")
+	pkg.ThrowIf(n.frozen, pkg.NewFrozen("n"))
+
+	n.Set(0)
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (n *Num[T]) Recycle(key *pkg.RecycleKey) {
+	n.poison.Revive(key)
+	n.Reset()
+}
+
+// Set sets the number value.
+//
+// Parameters:
+//   - value: The new value.
+func (n *Num[T]) Set(value T) {
+	pkg.Ensure(false, n)
+	pkg.ThrowIf(n.frozen, pkg.NewFrozen("n"))
+
+	old := n.value
+	n.value = value
+
+	if n.observers != nil {
+		n.observers.Notify(event.Set[T]{Index: -1, Old: old, New: value})
+	}
+}
+
+// is_float checks whether T is a floating-point type.
+//
+// Returns:
+//   - bool: True if T is a floating-point type, false otherwise.
+func is_float[T Number]() bool {
+	var one T = 1
+
+	return one/2 != 0
+}
+
+// is_signed checks whether T can hold negative values.
+//
+// Returns:
+//   - bool: True if T is signed or a floating-point type, false otherwise.
+func is_signed[T Number]() bool {
+	var zero T
+
+	return zero-1 < zero
+}
+
+// check_float throws if a floating-point operation on finite operands
+// overflowed to an infinity.
+//
+// Parameters:
+//   - op: The operation, for the error message.
+//   - a: The first operand.
+//   - b: The second operand.
+//   - result: The result.
+//
+// Throws:
+//   - *Overflow: If the result is infinite but the operands are not.
+func check_float[T Number](op string, a, b, result T) {
+	overflow := math.IsInf(float64(result), 0) && !math.IsInf(float64(a), 0) && !math.IsInf(float64(b), 0)
+	pkg.ThrowIf(overflow, new_overflow(op, a, b))
+}
+
+// new_overflow creates the error thrown when an operation overflows.
+//
+// Parameters:
+//   - op: The operation.
+//   - a: The first operand.
+//   - b: The second operand.
+//
+// Returns:
+//   - *pkg.Err: The new error. Never returns nil.
+func new_overflow[T Number](op string, a, b T) *pkg.Err {
+	return pkg.NewOverflow(fmt.Errorf("%v %s %v overflows %T", a, op, b, a))
+}
+
+// Add adds other to the number.
+//
+// Parameters:
+//   - other: The value to add.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+//
+// Throws:
+//   - *Overflow: If the result does not fit in T.
+func (n *Num[T]) Add(other T) *Num[T] {
+	pkg.Ensure(false, n)
+
+	a := n.value
+	result := a + other
+
+	if is_float[T]() {
+		check_float("+", a, other, result)
+	} else {
+		overflow := (other > 0 && result < a) || (other < 0 && result > a)
+		pkg.ThrowIf(overflow, new_overflow("+", a, other))
+	}
+
+	n.Set(result)
+
+	return n
+}
+
+// Sub subtracts other from the number.
+//
+// Parameters:
+//   - other: The value to subtract.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+//
+// Throws:
+//   - *Overflow: If the result does not fit in T.
+func (n *Num[T]) Sub(other T) *Num[T] {
+	pkg.Ensure(false, n)
+
+	a := n.value
+	result := a - other
+
+	if is_float[T]() {
+		check_float("-", a, other, result)
+	} else {
+		overflow := (other > 0 && result > a) || (other < 0 && result < a)
+		pkg.ThrowIf(overflow, new_overflow("-", a, other))
+	}
+
+	n.Set(result)
+
+	return n
+}
+
+// Mul multiplies the number by other.
+//
+// Parameters:
+//   - other: The value to multiply by.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+//
+// Throws:
+//   - *Overflow: If the result does not fit in T.
+func (n *Num[T]) Mul(other T) *Num[T] {
+	pkg.Ensure(false, n)
+
+	a := n.value
+	result := a * other
+
+	if is_float[T]() {
+		check_float("*", a, other, result)
+	} else if a != 0 && other != 0 {
+		// Checking both quotients catches MinInt * -1, whose first quotient
+		// wraps back to an operand.
+		overflow := result/a != other || result/other != a
+		pkg.ThrowIf(overflow, new_overflow("*", a, other))
+	}
+
+	n.Set(result)
+
+	return n
+}
+
+// Div divides the number by other. Integer division truncates toward zero.
+//
+// Parameters:
+//   - other: The value to divide by.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+//
+// Throws:
+//   - *DivisionByZero: If other is 0, even for floating-point types.
+//   - *Overflow: If the result does not fit in T, as in MinInt / -1.
+func (n *Num[T]) Div(other T) *Num[T] {
+	pkg.Ensure(false, n)
+	pkg.ThrowIf(other == 0, pkg.NewDivisionByZero())
+
+	a := n.value
+	result := a / other
+
+	if is_float[T]() {
+		check_float("/", a, other, result)
+	} else if is_signed[T]() {
+		// Only MinInt / -1 has the sign of its dividend when dividing by a
+		// negative number.
+		overflow := other < 0 && a < 0 && result < 0
+		pkg.ThrowIf(overflow, new_overflow("/", a, other))
+	}
+
+	n.Set(result)
+
+	return n
+}
+
+// Mod sets the number to the remainder of its division by other. The result
+// has the sign of the number, like the % operator and math.Mod.
+//
+// Parameters:
+//   - other: The divisor.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+//
+// Throws:
+//   - *DivisionByZero: If other is 0, even for floating-point types.
+func (n *Num[T]) Mod(other T) *Num[T] {
+	pkg.Ensure(false, n)
+	pkg.ThrowIf(other == 0, pkg.NewDivisionByZero())
+
+	a := n.value
+
+	var result T
+
+	if is_float[T]() {
+		result = T(math.Mod(float64(a), float64(other)))
+	} else {
+		// The % operator is not defined over Number. MinInt / -1 wraps to
+		// MinInt, which still gives the right remainder of 0.
+		result = a - (a/other)*other
+	}
+
+	n.Set(result)
+
+	return n
+}
+
+// Neg negates the number.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+//
+// Throws:
+//   - *Overflow: If the result does not fit in T, as in -MinInt or the
+//     negation of a non-zero unsigned number.
+func (n *Num[T]) Neg() *Num[T] {
+	pkg.Ensure(false, n)
+
+	a := n.value
+	result := -a
+
+	if !is_float[T]() && a != 0 {
+		overflow := !is_signed[T]() || result == a
+		pkg.ThrowIf(overflow, pkg.NewOverflow(fmt.Errorf("-%v overflows %T", a, a)))
+	}
+
+	n.Set(result)
+
+	return n
+}
+
+// Abs sets the number to its absolute value.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+//
+// Throws:
+//   - *Overflow: If the number is MinInt.
+func (n *Num[T]) Abs() *Num[T] {
+	pkg.Ensure(false, n)
+
+	if n.value < 0 || (is_float[T]() && math.Signbit(float64(n.value))) {
+		n.Neg()
+	}
+
+	return n
+}
+
+// Min sets the number to the smaller of itself and other. As for the min
+// built-in, NaN wins.
+//
+// Parameters:
+//   - other: The other value.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+func (n *Num[T]) Min(other T) *Num[T] {
+	pkg.Ensure(false, n)
+
+	n.Set(min(n.value, other))
+
+	return n
+}
+
+// Max sets the number to the larger of itself and other. As for the max
+// built-in, NaN wins.
+//
+// Parameters:
+//   - other: The other value.
+//
+// Returns:
+//   - *Num[T]: The number. Never returns nil.
+func (n *Num[T]) Max(other T) *Num[T] {
+	pkg.Ensure(false, n)
+
+	n.Set(max(n.value, other))
+
+	return n
+}
+
+// Compare implements the pkg.Comparer interface.
+//
+// NaN is less than any other value, as in cmp.Compare.
+func (n *Num[T]) Compare(other *Num[T]) int {
+	pkg.Ensure(false, n)
+	pkg.Ensure(false, other)
+
+	return cmp.Compare(n.value, other.value)
+}
+
+// ConvertNum converts a number to another numeric type.
+//
+// Parameters:
+//   - n: The number to convert.
+//
+// Returns:
+//   - *Num[U]: The converted number, as given by a Go conversion. Never
+//     returns nil.
+//   - error: An error if the conversion is lossy.
+//
+// Errors:
+//   - *LossyConversion: If the converted value differs from the original one,
+//     as when narrowing out of range, changing sign or dropping a fraction.
+//
+// Panics if n is nil.
+func ConvertNum[U Number, T Number](n *Num[T]) (*Num[U], error) {
+	pkg.Ensure(false, n)
+
+	value := n.value
+	converted := U(value)
+
+	// NaN is never equal to its conversion, so it is reported as lossy.
+	if T(converted) != value || (value < 0) != (converted < 0) {
+		err := pkg.NewLossyConversion(fmt.Errorf("%v (%T) cannot be represented as %T", value, value, converted))
+
+		return NewNum(converted), err
+	}
+
+	return NewNum(converted), nil
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (n *Num[T]) Freeze() {
+	pkg.Ensure(false, n)
+
+	n.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (n Num[T]) IsFrozen() bool {
+	return n.frozen
+}
+
+// Subscribe subscribes to the changes of the number.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Set[T] changes, synchronously after
+//     the mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (n *Num[T]) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, n)
+
+	if n.observers == nil {
+		n.observers = &event.Observers{}
+	}
+
+	return n.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the number into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (n *Num[T]) Batch(fn func()) {
+	pkg.Ensure(false, n)
+
+	n.observers.Batch(fn)
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Set[T].
+func (n *Num[T]) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, n)
+
+	change, ok := c.(event.Set[T])
+	pkg.ThrowIf(!ok, event.NewNotRevertible(c, n))
+
+	n.Set(change.Old)
+
+	return event.Set[T]{Index: -1, Old: change.New, New: change.Old}
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// code_of returns the error code fn panics with, or -1 if it does not panic.
+func code_of(fn func()) (code pkg.ErrorCode) {
+	code = -1
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		err, ok := r.(error)
+		if !ok {
+			panic(r)
+		}
+
+		var target *pkg.Err
+		if !errors.As(err, &target) {
+			panic(r)
+		}
+
+		code = target.Code
+	}()
+
+	fn()
+
+	return
+}
+
+func TestNumArithmetic(t *testing.T) {
+	n := NewNum(7).Add(3).Mul(4).Sub(5).Div(7).Mod(3)
+	if n.Value() != 2 {
+		t.Errorf("expected 2, got %d", n.Value())
+	}
+
+	if NewNum(-3).Abs().Value() != 3 || NewNum(2.5).Neg().Value() != -2.5 {
+		t.Errorf("unexpected Abs or Neg result")
+	}
+
+	if NewNum[uint](3).Min(2).Max(5).Value() != 5 {
+		t.Errorf("unexpected Min or Max result")
+	}
+
+	if NewNum(7.5).Mod(2).Value() != 1.5 {
+		t.Errorf("unexpected float Mod result")
+	}
+}
+
+func TestNumErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+		code pkg.ErrorCode
+	}{
+		{"add", func() { NewNum[int8](127).Add(1) }, pkg.Overflow},
+		{"sub unsigned", func() { NewNum[uint](1).Sub(2) }, pkg.Overflow},
+		{"mul", func() { NewNum[int64](math.MinInt64).Mul(-1) }, pkg.Overflow},
+		{"div", func() { NewNum[int32](math.MinInt32).Div(-1) }, pkg.Overflow},
+		{"neg", func() { NewNum[int16](math.MinInt16).Neg() }, pkg.Overflow},
+		{"neg unsigned", func() { NewNum[uint8](1).Neg() }, pkg.Overflow},
+		{"float", func() { NewNum(math.MaxFloat64).Mul(2) }, pkg.Overflow},
+		{"div zero", func() { NewNum(1).Div(0) }, pkg.DivisionByZero},
+		{"mod zero", func() { NewNum(1.0).Mod(0) }, pkg.DivisionByZero},
+		{"mod min", func() { NewNum[int8](math.MinInt8).Mod(-1) }, -1},
+	}
+
+	for _, test := range tests {
+		if code := code_of(test.fn); code != test.code {
+			t.Errorf("%s: expected %v, got %v", test.name, test.code, code)
+		}
+	}
+}
+
+func TestConvertNum(t *testing.T) {
+	if n, err := ConvertNum[int8](NewNum(100)); err != nil || n.Value() != 100 {
+		t.Errorf("expected a lossless conversion, got %v, %v", n, err)
+	}
+
+	for _, err := range []error{
+		get_err(ConvertNum[int8](NewNum(300))),
+		get_err(ConvertNum[uint](NewNum(-1))),
+		get_err(ConvertNum[int](NewNum(1.5))),
+		get_err(ConvertNum[float32](NewNum(math.NaN()))),
+	} {
+		var target *pkg.Err
+		if !errors.As(err, &target) || target.Code != pkg.LossyConversion {
+			t.Errorf("expected a LossyConversion error, got %v", err)
+		}
+	}
+
+	if IntFromNum(NewInt().WithValue(4).AsNum().Add(1)).Value() != 5 {
+		t.Errorf("expected Int and Num[int] to convert to each other")
+	}
+}
+
+// get_err returns the error of a two-valued call.
+func get_err[T any](_ T, err error) error {
+	return err
+}
@@ -0,0 +1,278 @@
+package types
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+)
+
+// OrderedIndex is a position in an ordered map, as slices.Index is for a
+// slice.
+type OrderedIndex[K, V pkg.Type] struct {
+	// pos is the position of the index in the map.
+	pos slices.Position
+
+	// ref is the map reference.
+	ref *OrderedMap[K, V]
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+func (idx *OrderedIndex[K, V]) String() string {
+	idx.poison.Check("idx")
+
+	var builder strings.Builder
+
+	builder.WriteString("OrderedIndex[")
+	builder.WriteString(idx.pos.String())
+	builder.WriteString(", ref=")
+	fmt.Fprintf(&builder, "%p", idx.ref)
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+//
+// The copy refers to the same map.
+func (idx *OrderedIndex[K, V]) DeepCopy() pkg.Type {
+	idx.poison.Check("idx")
+
+	return &OrderedIndex[K, V]{
+		pos: idx.pos,
+		ref: idx.ref,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (idx *OrderedIndex[K, V]) Ensure() {
+	pkg.ThrowIf(idx == nil, pkg.NewInvalidState("idx", pkg.NewNilValue()))
+	idx.poison.Check("idx")
+	pkg.ThrowIf(idx.ref == nil, pkg.NewInvalidState("idx.ref", pkg.NewNilValue()))
+}
+
+// Clean implements the pkg.Type interface.
+//
+// The map the index refers to is not cleaned.
+func (idx *OrderedIndex[K, V]) Clean() {
+	if idx == nil {
+		return
+	}
+
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	idx.ref = nil
+
+	idx.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two indexes are equal if they have the same value and refer to the same
+// map; regardless of the max value.
+func (idx *OrderedIndex[K, V]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, idx)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *OrderedIndex[K, V]:
+		return idx.pos.Value() == other.pos.Value() && idx.ref == other.ref
+	default:
+		return false
+	}
+}
+
+// NewOrderedIndex creates a new index at the first position of a map.
+//
+// Parameters:
+//   - m: The map reference.
+//
+// Returns:
+//   - *OrderedIndex[K, V]: The new index. Never returns nil.
+//
+// Panics if m is nil.
+func NewOrderedIndex[K, V pkg.Type](m *OrderedMap[K, V]) *OrderedIndex[K, V] {
+	pkg.Ensure(false, m)
+
+	return &OrderedIndex[K, V]{
+		ref: m,
+	}
+}
+
+// WithValue sets the index value.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *OrderedIndex[K, V]: The index. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If value is not less than the max value.
+func (idx *OrderedIndex[K, V]) WithValue(value int) *OrderedIndex[K, V] {
+	idx.Set(value)
+
+	return idx
+}
+
+// WithMax sets the index max value.
+//
+// Parameters:
+//   - max: The max value.
+//
+// Returns:
+//   - *OrderedIndex[K, V]: The index. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If max is greater than the size of the map.
+func (idx *OrderedIndex[K, V]) WithMax(max int) *OrderedIndex[K, V] {
+	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	idx.pos.SetMax(max, len(idx.ref.entries))
+
+	return idx
+}
+
+// WithoutMax makes the max value of the index follow the size of the map.
+//
+// Returns:
+//   - *OrderedIndex[K, V]: The index. Never returns nil.
+func (idx *OrderedIndex[K, V]) WithoutMax() *OrderedIndex[K, V] {
+	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	idx.pos.ClearMax()
+
+	return idx
+}
+
+// Value returns the index value.
+//
+// Returns:
+//   - int: The index value.
+func (idx *OrderedIndex[K, V]) Value() int {
+	idx.poison.Check("idx")
+
+	return idx.pos.Value()
+}
+
+// Max returns the index max value.
+//
+// Returns:
+//   - int: The index max value.
+func (idx *OrderedIndex[K, V]) Max() int {
+	pkg.Ensure(false, idx)
+
+	return idx.pos.Limit(len(idx.ref.entries))
+}
+
+// HasMax checks whether the index has an explicit max value.
+//
+// Returns:
+//   - bool: True if the max value was set with WithMax, false if the max value
+//     follows the size of the reference.
+func (idx *OrderedIndex[K, V]) HasMax() bool {
+	idx.poison.Check("idx")
+
+	return idx.pos.HasMax()
+}
+
+// Ref returns the map the index refers to.
+//
+// Returns:
+//   - *OrderedMap[K, V]: The map reference. Nil if the index was cleaned.
+func (idx *OrderedIndex[K, V]) Ref() *OrderedMap[K, V] {
+	idx.poison.Check("idx")
+
+	return idx.ref
+}
+
+// Set sets the index value.
+//
+// Parameters:
+//   - value: The new value.
+//
+// Throws:
+//   - *IllegalArgument: If value is not less than the max value.
+func (idx *OrderedIndex[K, V]) Set(value int) {
+	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	idx.pos.Set(value, len(idx.ref.entries))
+}
+
+// Next moves the index to the next position, if it is less than the max
+// value.
+//
+// Returns:
+//   - bool: True if the index moved, false otherwise.
+func (idx *OrderedIndex[K, V]) Next() bool {
+	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	return idx.pos.Next(len(idx.ref.entries))
+}
+
+// Prev moves the index to the previous position, if there is one.
+//
+// Returns:
+//   - bool: True if the index moved, false otherwise.
+func (idx *OrderedIndex[K, V]) Prev() bool {
+	pkg.Ensure(false, idx)
+	pkg.ThrowIf(idx.frozen, pkg.NewFrozen("idx"))
+
+	return idx.pos.Prev()
+}
+
+// Freeze implements the pkg.Freezer interface.
+//
+// The map the index refers to is not frozen.
+func (idx *OrderedIndex[K, V]) Freeze() {
+	pkg.Ensure(false, idx)
+
+	idx.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (idx *OrderedIndex[K, V]) IsFrozen() bool {
+	return idx.frozen
+}
+
+// Each creates an iterator that iterates over the index from the current value
+// up to the max value. The index itself is not moved.
+//
+// Returns:
+//   - iter.Seq[*OrderedIndex[K, V]]: The iterator. Never returns nil.
+func (idx *OrderedIndex[K, V]) Each() iter.Seq[*OrderedIndex[K, V]] {
+	pkg.Ensure(false, idx)
+
+	pos, ref := idx.pos, idx.ref
+
+	fn := func(yield func(*OrderedIndex[K, V]) bool) {
+		cursor := &OrderedIndex[K, V]{
+			pos: pos,
+			ref: ref,
+		}
+
+		if cursor.pos.Value() >= cursor.pos.Limit(len(ref.entries)) {
+			return
+		}
+
+		for {
+			if !yield(cursor) || !cursor.pos.Next(len(ref.entries)) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
@@ -0,0 +1,533 @@
+package types
+
+import (
+	"errors"
+	"iter"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// OrderedMap is a map whose keys are compared with Equals and kept in
+// insertion order. Lookups work as in Map; the entries can also be accessed
+// by position, with At or an OrderedIndex, which makes deletions and moves
+// linear in the size of the map.
+//
+// The keys are frozen when they are put in the map, if they implement
+// pkg.Freezer, since mutating a key would break the lookups.
+type OrderedMap[K, V pkg.Type] struct {
+	// entries are the entries of the map, in order.
+	entries []entry[K, V]
+
+	// index maps the hashes of the hashed keys to the positions of their
+	// entries.
+	index map[uint64][]int
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The entries are written in order, so the output is deterministic.
+func (m *OrderedMap[K, V]) String() string {
+	m.poison.Check("m")
+
+	var builder strings.Builder
+
+	builder.WriteString("OrderedMap[")
+
+	values := make([]string, 0, len(m.entries))
+	for _, e := range m.entries {
+		values = append(values, e.key.String()+": "+e.value.String())
+	}
+	builder.WriteString(strings.Join(values, ", "))
+
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+//
+// Both the keys and the values are copied, in the same order.
+func (m *OrderedMap[K, V]) DeepCopy() pkg.Type {
+	if m == nil {
+		return nil
+	}
+
+	m.poison.Check("m")
+
+	m_copy := NewOrderedMap[K, V]()
+
+	for _, e := range m.entries {
+		m_copy.Put(pkg.DeepCopy(e.key), pkg.DeepCopy(e.value))
+	}
+
+	return m_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (m *OrderedMap[K, V]) Ensure() {
+	pkg.ThrowIf(m == nil, pkg.NewInvalidState("m", pkg.NewNilValue()))
+
+	m.poison.Check("m")
+}
+
+// Clean implements the pkg.Type interface.
+//
+// Both the keys and the values are cleaned.
+func (m *OrderedMap[K, V]) Clean() {
+	if m == nil {
+		return
+	}
+
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	for _, e := range m.entries {
+		pkg.Clean(e.key)
+		pkg.Clean(e.value)
+	}
+
+	m.entries = nil
+	m.index = nil
+
+	m.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two ordered maps are equal if they have equal keys and values in the same
+// order.
+func (m *OrderedMap[K, V]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, other)
+
+	other_val, ok := other.(*OrderedMap[K, V])
+	if !ok {
+		return false
+	}
+
+	if len(m.entries) != len(other_val.entries) {
+		return false
+	}
+
+	for i, e := range m.entries {
+		other_e := other_val.entries[i]
+
+		if !e.key.Equals(other_e.key) || !e.value.Equals(other_e.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewOrderedMap creates a new empty ordered map.
+//
+// Returns:
+//   - *OrderedMap[K, V]: The new ordered map. Never returns nil.
+func NewOrderedMap[K, V pkg.Type]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		index: make(map[uint64][]int),
+	}
+}
+
+// reindex rebuilds the index after the entries moved.
+func (m *OrderedMap[K, V]) reindex() {
+	m.index = make(map[uint64][]int)
+
+	for i, e := range m.entries {
+		if e.hashed {
+			m.index[e.hash] = append(m.index[e.hash], i)
+		}
+	}
+}
+
+// Put associates a value with a key. If the key is already in the map, its
+// value is replaced and the key keeps its position; otherwise, the key is
+// added at the back.
+//
+// Parameters:
+//   - key: The key.
+//   - value: The value.
+//
+// Returns:
+//   - V: The previous value, which is not cleaned. The zero value if there was
+//     none.
+//   - bool: True if a previous value was replaced, false otherwise.
+//
+// Panics if key is nil.
+func (m *OrderedMap[K, V]) Put(key K, value V) (V, bool) {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+	pkg.Ensure(false, key)
+
+	if i := find_entry(m.entries, m.index, key); i >= 0 {
+		old := m.entries[i].value
+		m.entries[i].value = value
+
+		return old, true
+	}
+
+	e := new_entry(key, value)
+
+	if e.hashed {
+		if m.index == nil {
+			m.index = make(map[uint64][]int)
+		}
+
+		m.index[e.hash] = append(m.index[e.hash], len(m.entries))
+	}
+
+	m.entries = append(m.entries, e)
+
+	return *new(V), false
+}
+
+// Get returns the value associated with a key.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - V: The value. The zero value if the key is not in the map.
+//   - bool: True if the key is in the map, false otherwise.
+//
+// Panics if key is nil.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, key)
+
+	i := find_entry(m.entries, m.index, key)
+	if i < 0 {
+		return *new(V), false
+	}
+
+	return m.entries[i].value, true
+}
+
+// Has checks whether a key is in the map.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - bool: True if the key is in the map, false otherwise.
+//
+// Panics if key is nil.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, key)
+
+	return find_entry(m.entries, m.index, key) >= 0
+}
+
+// IndexOf returns the position of a key.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - int: The position, or -1 if the key is not in the map.
+//
+// Panics if key is nil.
+func (m *OrderedMap[K, V]) IndexOf(key K) int {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, key)
+
+	return find_entry(m.entries, m.index, key)
+}
+
+// At returns the entry at a position.
+//
+// Parameters:
+//   - i: The position.
+//
+// Returns:
+//   - K: The key.
+//   - V: The value.
+//
+// Throws:
+//   - *IllegalArgument: If i is out of range.
+func (m *OrderedMap[K, V]) At(i int) (K, V) {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(i < 0 || i >= len(m.entries), pkg.NewIllegalArgument(errors.New("index out of range")))
+
+	e := m.entries[i]
+
+	return e.key, e.value
+}
+
+// Index returns an index at the first position of the map.
+//
+// Returns:
+//   - *OrderedIndex[K, V]: The index. Never returns nil.
+func (m *OrderedMap[K, V]) Index() *OrderedIndex[K, V] {
+	return NewOrderedIndex(m)
+}
+
+// EntryAt returns the entry at an index.
+//
+// Parameters:
+//   - i: The index.
+//
+// Returns:
+//   - K: The key.
+//   - V: The value.
+//
+// Throws:
+//   - *IllegalArgument: If i refers to another map or is out of range.
+//
+// Panics if i is nil.
+func (m *OrderedMap[K, V]) EntryAt(i *OrderedIndex[K, V]) (K, V) {
+	pkg.Ensure(false, m)
+	pkg.Ensure(false, i)
+	pkg.ThrowIf(i.ref != m, pkg.NewIllegalArgument(errors.New("index refers to another map")))
+
+	return m.At(i.pos.Value())
+}
+
+// Delete removes a key and its value from the map. Neither of them is
+// cleaned. The following entries keep their order.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - V: The removed value. The zero value if the key was not in the map.
+//   - bool: True if the key was in the map, false otherwise.
+//
+// Panics if key is nil.
+func (m *OrderedMap[K, V]) Delete(key K) (V, bool) {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+	pkg.Ensure(false, key)
+
+	i := find_entry(m.entries, m.index, key)
+	if i < 0 {
+		return *new(V), false
+	}
+
+	value := m.entries[i].value
+
+	copy(m.entries[i:], m.entries[i+1:])
+	m.entries[len(m.entries)-1] = entry[K, V]{}
+	m.entries = m.entries[:len(m.entries)-1]
+
+	m.reindex()
+
+	return value, true
+}
+
+// move moves an entry to another position, shifting the ones in between.
+//
+// Parameters:
+//   - from: The position of the entry.
+//   - to: The new position of the entry.
+func (m *OrderedMap[K, V]) move(from, to int) {
+	if from == to {
+		return
+	}
+
+	e := m.entries[from]
+
+	if from < to {
+		copy(m.entries[from:to], m.entries[from+1:to+1])
+	} else {
+		copy(m.entries[to+1:from+1], m.entries[to:from])
+	}
+
+	m.entries[to] = e
+
+	m.reindex()
+}
+
+// MoveToFront moves a key to the first position.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - bool: True if the key is in the map, false otherwise.
+//
+// Panics if key is nil.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+	pkg.Ensure(false, key)
+
+	i := find_entry(m.entries, m.index, key)
+	if i < 0 {
+		return false
+	}
+
+	m.move(i, 0)
+
+	return true
+}
+
+// MoveToBack moves a key to the last position.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - bool: True if the key is in the map, false otherwise.
+//
+// Panics if key is nil.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	pkg.Ensure(false, m)
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+	pkg.Ensure(false, key)
+
+	i := find_entry(m.entries, m.index, key)
+	if i < 0 {
+		return false
+	}
+
+	m.move(i, len(m.entries)-1)
+
+	return true
+}
+
+// IsEmpty checks whether the map is empty.
+//
+// Returns:
+//   - bool: True if the map is empty, false otherwise.
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	m.poison.Check("m")
+
+	return len(m.entries) == 0
+}
+
+// Size returns the number of keys in the map.
+//
+// Returns:
+//   - int: The number of keys in the map.
+func (m *OrderedMap[K, V]) Size() int {
+	m.poison.Check("m")
+
+	return len(m.entries)
+}
+
+// Reset removes all entries from the map without cleaning them.
+func (m *OrderedMap[K, V]) Reset() {
+	if m == nil {
+		return
+	}
+
+	m.poison.Check("m")
+	pkg.ThrowIf(m.frozen, pkg.NewFrozen("m"))
+
+	clear(m.entries)
+	m.entries = m.entries[:0]
+	m.index = make(map[uint64][]int)
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (m *OrderedMap[K, V]) Recycle(key *pkg.RecycleKey) {
+	m.poison.Revive(key)
+	m.Reset()
+}
+
+// Keys returns an iterator over the keys of the map, in order.
+//
+// Returns:
+//   - iter.Seq[K]: The iterator. Never returns nil.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	m.poison.Check("m")
+
+	fn := func(yield func(K) bool) {
+		for _, e := range m.entries {
+			if !yield(e.key) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Values returns an iterator over the values of the map, in order.
+//
+// Returns:
+//   - iter.Seq[V]: The iterator. Never returns nil.
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	m.poison.Check("m")
+
+	fn := func(yield func(V) bool) {
+		for _, e := range m.entries {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// All returns an iterator over the entries of the map, in order.
+//
+// Returns:
+//   - iter.Seq2[K, V]: The iterator. Never returns nil.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	m.poison.Check("m")
+
+	fn := func(yield func(K, V) bool) {
+		for _, e := range m.entries {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Backward returns an iterator over the entries of the map, in reverse
+// order.
+//
+// Returns:
+//   - iter.Seq2[K, V]: The iterator. Never returns nil.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	m.poison.Check("m")
+
+	fn := func(yield func(K, V) bool) {
+		for i := len(m.entries) - 1; i >= 0; i-- {
+			e := m.entries[i]
+
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Freeze implements the pkg.Freezer interface.
+//
+// The values that implement pkg.Freezer are frozen as well; the keys already
+// are.
+func (m *OrderedMap[K, V]) Freeze() {
+	pkg.Ensure(false, m)
+
+	if m.frozen {
+		return
+	}
+
+	m.frozen = true
+
+	for _, e := range m.entries {
+		pkg.Freeze(e.value)
+	}
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (m *OrderedMap[K, V]) IsFrozen() bool {
+	return m.frozen
+}
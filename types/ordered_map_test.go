@@ -0,0 +1,108 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap[*String, *Int]()
+
+	for i, name := range []string{"a", "b", "c", "d"} {
+		m.Put(NewString(name), NewInt().WithValue(i))
+	}
+
+	m.Put(NewString("b"), NewInt().WithValue(9))
+	m.Delete(NewString("a"))
+	m.MoveToFront(NewString("d"))
+	m.MoveToBack(NewString("b"))
+
+	if m.String() != "OrderedMap[d: 3, c: 2, b: 9]" {
+		t.Fatalf("unexpected map: %s", m)
+	}
+
+	if key, value := m.At(1); key.Value() != "c" || value.Value() != 2 {
+		t.Errorf("expected c: 2 at 1, got %s: %s", key, value)
+	}
+
+	if m.IndexOf(NewString("b")) != 2 || m.IndexOf(NewString("a")) != -1 {
+		t.Errorf("unexpected positions after the moves")
+	}
+
+	var keys []string
+	for k := range m.Backward() {
+		keys = append(keys, k.Value())
+	}
+
+	if len(keys) != 3 || keys[0] != "b" || keys[2] != "d" {
+		t.Errorf("unexpected reverse order: %v", keys)
+	}
+}
+
+func TestOrderedMapEquals(t *testing.T) {
+	a := NewOrderedMap[*Int, *Int]()
+	a.Put(NewInt().WithValue(1), NewInt().WithValue(1))
+	a.Put(NewInt().WithValue(2), NewInt().WithValue(2))
+
+	b := a.DeepCopy().(*OrderedMap[*Int, *Int])
+	if !a.Equals(b) {
+		t.Fatalf("expected %s to equal its copy", a)
+	}
+
+	b.MoveToFront(NewInt().WithValue(2))
+	if a.Equals(b) {
+		t.Errorf("expected the order to matter: %s and %s", a, b)
+	}
+}
+
+func TestOrderedIndex(t *testing.T) {
+	m := NewOrderedMap[*String, *Int]()
+
+	for i, name := range []string{"a", "b", "c"} {
+		m.Put(NewString(name), NewInt().WithValue(i))
+	}
+
+	idx := m.Index()
+
+	var keys []string
+
+	for i := range idx.Each() {
+		k, _ := m.EntryAt(i)
+		keys = append(keys, k.Value())
+	}
+
+	if strings.Join(keys, "") != "abc" || idx.Value() != 0 {
+		t.Fatalf("expected the keys abc from an unmoved index, got %v at %d", keys, idx.Value())
+	}
+
+	if !idx.Next() || !idx.Next() || idx.Next() {
+		t.Errorf("expected Next to stop at the last entry")
+	}
+
+	if k, v := m.EntryAt(idx); k.Value() != "c" || v.Value() != 2 {
+		t.Errorf("expected c: 2, got %s: %s", k, v)
+	}
+
+	idx.WithMax(2)
+	if idx.Max() != 2 || idx.Next() {
+		t.Errorf("expected the max value to bound the index")
+	}
+
+	if !idx.Prev() || idx.Value() != 1 {
+		t.Errorf("expected Prev to move back to 1, got %d", idx.Value())
+	}
+
+	idx.WithValue(0)
+	if idx.Prev() {
+		t.Errorf("expected Prev to stop at the first entry")
+	}
+
+	other := NewOrderedMap[*String, *Int]()
+	other.Put(NewString("a"), NewInt())
+
+	if code := code_of(func() { other.EntryAt(idx) }); code != pkg.IllegalArgument {
+		t.Errorf("expected IllegalArgument, got %v", code)
+	}
+}
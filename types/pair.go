@@ -0,0 +1,320 @@
+package types
+
+import "github.com/PlayerR9/GoSD/pkg"
+
+// Pair is an ordered pair of values.
+type Pair[A, B pkg.Type] struct {
+	// first is the first value.
+	first A
+
+	// second is the second value.
+	second B
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The pair is written as "(first, second)".
+func (p *Pair[A, B]) String() string {
+	p.poison.Check("p")
+
+	return "(" + p.first.String() + ", " + p.second.String() + ")"
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (p *Pair[A, B]) DeepCopy() pkg.Type {
+	p.poison.Check("p")
+
+	return &Pair[A, B]{
+		first:  pkg.DeepCopy(p.first),
+		second: pkg.DeepCopy(p.second),
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+//
+// Both values are ensured.
+func (p *Pair[A, B]) Ensure() {
+	pkg.ThrowIf(p == nil, pkg.NewInvalidState("p", pkg.NewNilValue()))
+
+	p.poison.Check("p")
+
+	pkg.Ensure(false, p.first)
+	pkg.Ensure(false, p.second)
+}
+
+// Clean implements the pkg.Type interface.
+//
+// Both values are cleaned.
+func (p *Pair[A, B]) Clean() {
+	if p == nil {
+		return
+	}
+
+	pkg.Clean(p.first)
+	pkg.Clean(p.second)
+
+	p.first = *new(A)
+	p.second = *new(B)
+
+	p.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two pairs are equal if their values are equal, one by one.
+func (p *Pair[A, B]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, p)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Pair[A, B]:
+		return p.first.Equals(other.first) && p.second.Equals(other.second)
+	default:
+		return false
+	}
+}
+
+// NewPair creates a new pair.
+//
+// Parameters:
+//   - first: The first value.
+//   - second: The second value.
+//
+// Returns:
+//   - *Pair[A, B]: The new pair. Never returns nil.
+//
+// Panics if first or second is nil.
+func NewPair[A, B pkg.Type](first A, second B) *Pair[A, B] {
+	pkg.Ensure(false, first)
+	pkg.Ensure(false, second)
+
+	return &Pair[A, B]{
+		first:  first,
+		second: second,
+	}
+}
+
+// First returns the first value.
+//
+// Returns:
+//   - A: The first value.
+func (p *Pair[A, B]) First() A {
+	pkg.Ensure(false, p)
+
+	return p.first
+}
+
+// Second returns the second value.
+//
+// Returns:
+//   - B: The second value.
+func (p *Pair[A, B]) Second() B {
+	pkg.Ensure(false, p)
+
+	return p.second
+}
+
+// Compare implements the pkg.Comparer interface.
+//
+// The pairs are ordered lexicographically: by their first values, then by
+// their second ones.
+//
+// Throws:
+//   - *IllegalArgument: If A or B does not implement pkg.Comparer.
+func (p *Pair[A, B]) Compare(other *Pair[A, B]) int {
+	pkg.Ensure(false, p)
+	pkg.Ensure(false, other)
+
+	if c := pkg.Compare(p.first, other.first); c != 0 {
+		return c
+	}
+
+	return pkg.Compare(p.second, other.second)
+}
+
+// CartesianProduct returns the set of the pairs (x, y) for every x in a and y
+// in b, made of deep copies of the elements. There is no in-place form, as
+// the element type changes.
+//
+// Parameters:
+//   - a: The first set. If nil, it is treated as empty.
+//   - b: The second set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *Set[*Pair[A, B]]: The new set. Never returns nil.
+func CartesianProduct[A, B pkg.Type](a *Set[A], b *Set[B]) *Set[*Pair[A, B]] {
+	result := NewSet[*Pair[A, B]]()
+
+	if a == nil || b == nil {
+		return result
+	}
+
+	for _, x := range a.values {
+		for _, y := range b.values {
+			pair := NewPair(pkg.DeepCopy(x), pkg.DeepCopy(y))
+			result.values = append(result.values, pair)
+		}
+	}
+
+	return result
+}
+
+// Triple is an ordered triple of values.
+type Triple[A, B, C pkg.Type] struct {
+	// first is the first value.
+	first A
+
+	// second is the second value.
+	second B
+
+	// third is the third value.
+	third C
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The triple is written as "(first, second, third)".
+func (t *Triple[A, B, C]) String() string {
+	t.poison.Check("t")
+
+	return "(" + t.first.String() + ", " + t.second.String() + ", " + t.third.String() + ")"
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (t *Triple[A, B, C]) DeepCopy() pkg.Type {
+	t.poison.Check("t")
+
+	return &Triple[A, B, C]{
+		first:  pkg.DeepCopy(t.first),
+		second: pkg.DeepCopy(t.second),
+		third:  pkg.DeepCopy(t.third),
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+//
+// The three values are ensured.
+func (t *Triple[A, B, C]) Ensure() {
+	pkg.ThrowIf(t == nil, pkg.NewInvalidState("t", pkg.NewNilValue()))
+
+	t.poison.Check("t")
+
+	pkg.Ensure(false, t.first)
+	pkg.Ensure(false, t.second)
+	pkg.Ensure(false, t.third)
+}
+
+// Clean implements the pkg.Type interface.
+//
+// The three values are cleaned.
+func (t *Triple[A, B, C]) Clean() {
+	if t == nil {
+		return
+	}
+
+	pkg.Clean(t.first)
+	pkg.Clean(t.second)
+	pkg.Clean(t.third)
+
+	t.first = *new(A)
+	t.second = *new(B)
+	t.third = *new(C)
+
+	t.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two triples are equal if their values are equal, one by one.
+func (t *Triple[A, B, C]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, t)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Triple[A, B, C]:
+		return t.first.Equals(other.first) && t.second.Equals(other.second) && t.third.Equals(other.third)
+	default:
+		return false
+	}
+}
+
+// NewTriple creates a new triple.
+//
+// Parameters:
+//   - first: The first value.
+//   - second: The second value.
+//   - third: The third value.
+//
+// Returns:
+//   - *Triple[A, B, C]: The new triple. Never returns nil.
+//
+// Panics if any value is nil.
+func NewTriple[A, B, C pkg.Type](first A, second B, third C) *Triple[A, B, C] {
+	pkg.Ensure(false, first)
+	pkg.Ensure(false, second)
+	pkg.Ensure(false, third)
+
+	return &Triple[A, B, C]{
+		first:  first,
+		second: second,
+		third:  third,
+	}
+}
+
+// First returns the first value.
+//
+// Returns:
+//   - A: The first value.
+func (t *Triple[A, B, C]) First() A {
+	pkg.Ensure(false, t)
+
+	return t.first
+}
+
+// Second returns the second value.
+//
+// Returns:
+//   - B: The second value.
+func (t *Triple[A, B, C]) Second() B {
+	pkg.Ensure(false, t)
+
+	return t.second
+}
+
+// Third returns the third value.
+//
+// Returns:
+//   - C: The third value.
+func (t *Triple[A, B, C]) Third() C {
+	pkg.Ensure(false, t)
+
+	return t.third
+}
+
+// Compare implements the pkg.Comparer interface.
+//
+// The triples are ordered lexicographically, from the first values to the
+// third ones.
+//
+// Throws:
+//   - *IllegalArgument: If A, B or C does not implement pkg.Comparer.
+func (t *Triple[A, B, C]) Compare(other *Triple[A, B, C]) int {
+	pkg.Ensure(false, t)
+	pkg.Ensure(false, other)
+
+	if c := pkg.Compare(t.first, other.first); c != 0 {
+		return c
+	}
+
+	if c := pkg.Compare(t.second, other.second); c != 0 {
+		return c
+	}
+
+	return pkg.Compare(t.third, other.third)
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+)
+
+func TestPairCompare(t *testing.T) {
+	pair := func(a int, b string) *Pair[*Int, *String] {
+		return NewPair(NewInt().WithValue(a), NewString(b))
+	}
+
+	tests := []struct {
+		a, b *Pair[*Int, *String]
+		want int
+	}{
+		{pair(1, "b"), pair(2, "a"), -1},
+		{pair(2, "b"), pair(2, "a"), 1},
+		{pair(2, "a"), pair(2, "a"), 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%s vs %s: expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+
+	unordered := NewPair(NewBool(), NewInt())
+	if code := code_of(func() { unordered.Compare(unordered) }); code != pkg.IllegalArgument {
+		t.Errorf("expected IllegalArgument, got %v", code)
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	product := CartesianProduct(int_set(1, 2), int_set(3, 4, 5))
+	if product.Size() != 6 || !product.Has(NewPair(NewInt().WithValue(2), NewInt().WithValue(5))) {
+		t.Errorf("unexpected product: %s", product)
+	}
+
+	if empty := CartesianProduct[*Int, *Int](int_set(1), nil); !empty.IsEmpty() {
+		t.Errorf("expected an empty product, got %s", empty)
+	}
+}
+
+func TestTriple(t *testing.T) {
+	a := NewTriple(NewInt().WithValue(1), NewString("x"), NewInt().WithValue(3))
+	b := a.DeepCopy().(*Triple[*Int, *String, *Int])
+
+	if a.String() != "(1, x, 3)" || !a.Equals(b) || a.Compare(b) != 0 {
+		t.Fatalf("expected %s to equal its copy %s", a, b)
+	}
+
+	b.Third().Set(4)
+	if a.Compare(b) != -1 || a.Equals(b) {
+		t.Errorf("expected %s < %s", a, b)
+	}
+}
+
+func TestPairEnsure(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	cleaned := NewInt()
+	p := NewPair(cleaned, NewString("x"))
+	cleaned.Clean()
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"nil first", func() { (&Pair[*Int, *String]{second: NewString("x")}).Ensure() }},
+		{"nil second", func() { (&Pair[*Int, *String]{first: NewInt()}).Ensure() }},
+		{"cleaned first", func() { p.Ensure() }},
+	}
+
+	for _, tt := range tests {
+		if code := code_of(tt.fn); code != pkg.InvalidState {
+			t.Errorf("%s: expected InvalidState, got %v", tt.name, code)
+		}
+	}
+}
+
+func TestTripleEnsure(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	cleaned := NewInt()
+	tr := NewTriple(NewInt(), NewString("x"), cleaned)
+	cleaned.Clean()
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"nil second", func() { (&Triple[*Int, *String, *Int]{first: NewInt(), third: NewInt()}).Ensure() }},
+		{"nil third", func() { (&Triple[*Int, *String, *Int]{first: NewInt(), second: NewString("x")}).Ensure() }},
+		{"cleaned third", func() { tr.Ensure() }},
+	}
+
+	for _, tt := range tests {
+		if code := code_of(tt.fn); code != pkg.InvalidState {
+			t.Errorf("%s: expected InvalidState, got %v", tt.name, code)
+		}
+	}
+}
+
+func TestZip(t *testing.T) {
+	ints := slices.NewSlice[*Int]().WithValue([]*Int{NewInt().WithValue(1), NewInt().WithValue(2)})
+	strs := slices.NewSlice[*String]().WithValue([]*String{NewString("a"), NewString("b")})
+
+	pairs := slices.Zip(ints, strs, NewPair)
+	if pairs.String() != "Slice[(1, a), (2, b)]" {
+		t.Fatalf("unexpected pairs: %s", pairs)
+	}
+
+	a, b := slices.Unzip(pairs)
+	if !a.Equals(ints) || !b.Equals(strs) {
+		t.Errorf("expected the round trip to give back %s and %s, got %s and %s", ints, strs, a, b)
+	}
+
+	if code := code_of(func() { slices.Zip(ints, slices.NewSlice[*String](), NewPair) }); code != pkg.IllegalArgument {
+		t.Errorf("expected IllegalArgument, got %v", code)
+	}
+}
@@ -0,0 +1,179 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Parse parses a value from its String() form.
+//
+// Parameters:
+//   - text: The text to parse.
+//   - fn: The function that parses the value, such as ScanInt or ScanSet(ScanBool).
+//
+// Returns:
+//   - T: The parsed value.
+//   - error: An IllegalArgument error wrapping a *pkg.ErrParse if the text is
+//     not a valid value.
+//
+// Example:
+//
+//	set, err := Parse("Set[1, 2]", ScanSet(ScanInt))
+func Parse[T pkg.Type](text string, fn pkg.ParseFunc[T]) (T, error) {
+	return pkg.Parse(text, fn)
+}
+
+// ScanInt parses an int in the form printed by Int.String().
+//
+// Parameters:
+//   - s: The scanner.
+//
+// Returns:
+//   - *Int: The parsed int. Nil if an error occurred.
+//   - error: An error if the text is not a valid int.
+func ScanInt(s *pkg.Scanner) (*Int, error) {
+	line, column := s.Position()
+
+	var builder strings.Builder
+
+	if s.Accept("-") {
+		builder.WriteRune('-')
+	} else {
+		s.Accept("+")
+	}
+
+	digits := s.Until(func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if digits == "" {
+		return nil, s.Errorf("expected a digit")
+	}
+
+	builder.WriteString(digits)
+
+	value, err := strconv.Atoi(builder.String())
+	if err != nil {
+		return nil, s.ErrorAt(line, column, err)
+	}
+
+	return NewInt().WithValue(value), nil
+}
+
+// ScanBool parses a bool in the form printed by Bool.String(), that is, "T"
+// or "F".
+//
+// Parameters:
+//   - s: The scanner.
+//
+// Returns:
+//   - *Bool: The parsed bool. Nil if an error occurred.
+//   - error: An error if the text is not a valid bool.
+func ScanBool(s *pkg.Scanner) (*Bool, error) {
+	if s.Accept("T") {
+		return NewBool().WithValue(true), nil
+	} else if s.Accept("F") {
+		return NewBool().WithValue(false), nil
+	}
+
+	return nil, s.Errorf("expected \"T\" or \"F\"")
+}
+
+// ScanEnum creates a function that parses an enum by the literal
+// representation of one of the given values.
+//
+// Parameters:
+//   - values: The accepted values.
+//
+// Returns:
+//   - pkg.ParseFunc[*Enum[T]]: The parse function. Never returns nil.
+//
+// When several literals match, the longest one wins.
+func ScanEnum[T Enumer](values ...T) pkg.ParseFunc[*Enum[T]] {
+	fn := func(s *pkg.Scanner) (*Enum[T], error) {
+		var best T
+		var best_len int
+
+		for _, v := range values {
+			lit := v.String()
+
+			if len(lit) > best_len && s.HasPrefix(lit) {
+				best = v
+				best_len = len(lit)
+			}
+		}
+
+		if best_len == 0 {
+			return nil, s.Errorf("expected an enum value")
+		}
+
+		s.Accept(best.String())
+
+		return NewEnum(best), nil
+	}
+
+	return fn
+}
+
+// ScanWrap creates a function that parses a wrap from the text up to the next
+// ',', ']' or newline.
+//
+// Parameters:
+//   - conv: The function that converts the text into the wrapped value.
+//
+// Returns:
+//   - pkg.ParseFunc[*Wrap[T]]: The parse function. Never returns nil.
+//
+// Panics if conv is nil. Wrapped values whose String() form contains one of the
+// delimiters cannot be read back.
+func ScanWrap[T comparable](conv func(str string) (T, error)) pkg.ParseFunc[*Wrap[T]] {
+	pkg.ThrowIf(conv == nil, pkg.NewInvalidCall("conv", pkg.NewNilValue()))
+
+	fn := func(s *pkg.Scanner) (*Wrap[T], error) {
+		line, column := s.Position()
+
+		str := s.Until(func(r rune) bool {
+			return r == ',' || r == ']' || r == '\n'
+		})
+
+		value, err := conv(str)
+		if err != nil {
+			return nil, s.ErrorAt(line, column, err)
+		}
+
+		return NewWrap(value), nil
+	}
+
+	return fn
+}
+
+// ScanSet creates a function that parses a set in the form printed by
+// Set.String().
+//
+// Parameters:
+//   - elem: The function that parses the elements.
+//
+// Returns:
+//   - pkg.ParseFunc[*Set[T]]: The parse function. Never returns nil.
+//
+// Panics if elem is nil. Duplicate elements are dropped.
+func ScanSet[T pkg.Type](elem pkg.ParseFunc[T]) pkg.ParseFunc[*Set[T]] {
+	pkg.ThrowIf(elem == nil, pkg.NewInvalidCall("elem", pkg.NewNilValue()))
+
+	fn := func(s *pkg.Scanner) (*Set[T], error) {
+		err := s.Expect("Set[")
+		if err != nil {
+			return nil, err
+		}
+
+		elems, err := pkg.ScanList(s, elem)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSet[T]().WithValue(elems), nil
+	}
+
+	return fn
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	set := NewSet[*Int]().WithValue([]*Int{NewInt().WithValue(1), NewInt().WithValue(-20)})
+
+	got_set, err := Parse(set.String(), ScanSet(ScanInt))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !set.Equals(got_set) {
+		t.Errorf("expected %s, got %s", set, got_set)
+	}
+
+	nested := slices.NewSlice[*Set[*Bool]]().WithValue([]*Set[*Bool]{
+		NewSet[*Bool]().WithValue([]*Bool{NewBool().WithValue(true), NewBool()}),
+		NewSet[*Bool](),
+	})
+
+	got_nested, err := slices.Parse(nested.String(), ScanSet(ScanBool))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !nested.Equals(got_nested) {
+		t.Errorf("expected %s, got %s", nested, got_nested)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	const text = "Slice[Set[T, F],\nSet[T, X]]"
+
+	_, err := slices.Parse(text, ScanSet(ScanBool))
+
+	perr := pkg.AsParseError(err)
+	if perr == nil {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+
+	if perr.Line != 2 || perr.Column != 8 {
+		t.Errorf("expected line 2, column 8, got line %d, column %d", perr.Line, perr.Column)
+	}
+}
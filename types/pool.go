@@ -0,0 +1,20 @@
+package types
+
+import "github.com/PlayerR9/GoSD/pkg"
+
+var (
+	// IntPool is the pool of ints. Values returned by Get are 0.
+	IntPool *pkg.Pool[*Int] = pkg.NewPool(NewInt)
+
+	// BoolPool is the pool of bools. Values returned by Get are false.
+	BoolPool *pkg.Pool[*Bool] = pkg.NewPool(NewBool)
+)
+
+// SetPool returns the shared pool of sets of T. Values returned by Get are
+// empty and Put cleans the elements of the set.
+//
+// Returns:
+//   - *pkg.Pool[*Set[T]]: The pool. Never returns nil.
+func SetPool[T pkg.Type]() *pkg.Pool[*Set[T]] {
+	return pkg.SharedPool(NewSet[T])
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+func TestPool(t *testing.T) {
+	pool := pkg.NewPool(NewSet[*Int]).WithDebug(true)
+
+	s := pool.Get()
+	s.Add(NewInt().WithValue(1))
+	pool.Put(s)
+
+	s = pool.Get()
+	if !s.IsEmpty() {
+		t.Errorf("expected an empty set, got %s", s)
+	}
+
+	pool.Put(s)
+	pool.Put(s)
+
+	// Never returned.
+	pool.Get()
+	pool.Get()
+
+	problems := pool.Report()
+	if len(problems) != 3 {
+		t.Fatalf("expected 3 problems, got %d: %v", len(problems), problems)
+	}
+}
@@ -0,0 +1,288 @@
+package types
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"strconv"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Range is a half-open range of integers [lo, hi). Ranges are immutable; the
+// operations return new ranges.
+type Range struct {
+	// lo is the first integer of the range.
+	lo int
+
+	// hi is the integer right after the last one of the range.
+	hi int
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The range is written as "[lo, hi)".
+func (r *Range) String() string {
+	r.poison.Check("r")
+
+	return "[" + strconv.Itoa(r.lo) + ", " + strconv.Itoa(r.hi) + ")"
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (r *Range) DeepCopy() pkg.Type {
+	r.poison.Check("r")
+
+	return &Range{
+		lo: r.lo,
+		hi: r.hi,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (r *Range) Ensure() {
+	pkg.ThrowIf(r == nil, pkg.NewInvalidState("r", pkg.NewNilValue()))
+
+	r.poison.Check("r")
+}
+
+// Clean implements the pkg.Type interface.
+func (r *Range) Clean() {
+	if r == nil {
+		return
+	}
+
+	r.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two ranges are equal if they have the same bounds or if both are empty.
+func (r *Range) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, r)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Range:
+		if r.IsEmpty() && other.IsEmpty() {
+			return true
+		}
+
+		return r.lo == other.lo && r.hi == other.hi
+	default:
+		return false
+	}
+}
+
+// NewRange creates a new range.
+//
+// Parameters:
+//   - lo: The first integer of the range.
+//   - hi: The integer right after the last one. Equal to lo for an empty
+//     range.
+//
+// Returns:
+//   - *Range: The new range. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If hi is less than lo.
+func NewRange(lo, hi int) *Range {
+	pkg.ThrowIf(hi < lo, pkg.NewIllegalArgument(errors.New("range upper bound is less than its lower bound")))
+
+	return &Range{
+		lo: lo,
+		hi: hi,
+	}
+}
+
+// Lo returns the first integer of the range.
+//
+// Returns:
+//   - int: The lower bound, inclusive.
+func (r *Range) Lo() int {
+	r.poison.Check("r")
+
+	return r.lo
+}
+
+// Hi returns the integer right after the last one of the range.
+//
+// Returns:
+//   - int: The upper bound, exclusive.
+func (r *Range) Hi() int {
+	r.poison.Check("r")
+
+	return r.hi
+}
+
+// Len returns the number of integers in the range.
+//
+// Returns:
+//   - int: The number of integers.
+func (r *Range) Len() int {
+	r.poison.Check("r")
+
+	return r.hi - r.lo
+}
+
+// IsEmpty checks whether the range is empty.
+//
+// Returns:
+//   - bool: True if the range is empty, false otherwise.
+func (r *Range) IsEmpty() bool {
+	r.poison.Check("r")
+
+	return r.lo == r.hi
+}
+
+// Contains checks whether an integer is in the range.
+//
+// Parameters:
+//   - x: The integer.
+//
+// Returns:
+//   - bool: True if lo <= x < hi, false otherwise.
+func (r *Range) Contains(x int) bool {
+	pkg.Ensure(false, r)
+
+	return r.lo <= x && x < r.hi
+}
+
+// Overlaps checks whether the range has integers in common with another.
+//
+// Parameters:
+//   - other: The other range.
+//
+// Returns:
+//   - bool: True if the ranges overlap, false otherwise.
+//
+// Panics if other is nil.
+func (r *Range) Overlaps(other *Range) bool {
+	pkg.Ensure(false, r)
+	pkg.Ensure(false, other)
+
+	return max(r.lo, other.lo) < min(r.hi, other.hi)
+}
+
+// Intersect returns the integers that the range has in common with another.
+//
+// Parameters:
+//   - other: The other range.
+//
+// Returns:
+//   - *Range: The intersection. Empty if the ranges do not overlap. Never
+//     returns nil.
+//
+// Panics if other is nil.
+func (r *Range) Intersect(other *Range) *Range {
+	pkg.Ensure(false, r)
+	pkg.Ensure(false, other)
+
+	lo := max(r.lo, other.lo)
+	hi := min(r.hi, other.hi)
+
+	if hi < lo {
+		hi = lo
+	}
+
+	return &Range{
+		lo: lo,
+		hi: hi,
+	}
+}
+
+// Split splits the range at an integer.
+//
+// Parameters:
+//   - at: The integer where the second range starts.
+//
+// Returns:
+//   - *Range: The range [lo, at). Never returns nil.
+//   - *Range: The range [at, hi). Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If at is not in [lo, hi].
+func (r *Range) Split(at int) (*Range, *Range) {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(at < r.lo || at > r.hi, pkg.NewIllegalArgument(errors.New("split point is out of range")))
+
+	return &Range{lo: r.lo, hi: at}, &Range{lo: at, hi: r.hi}
+}
+
+// Each returns an iterator over the integers of the range, in increasing
+// order.
+//
+// Returns:
+//   - iter.Seq[int]: The iterator. Never returns nil.
+func (r *Range) Each() iter.Seq[int] {
+	return r.Step(1)
+}
+
+// Step returns an iterator over every step-th integer of the range. A
+// positive step goes up from lo; a negative one goes down from hi - 1.
+//
+// Parameters:
+//   - step: The distance between two integers.
+//
+// Returns:
+//   - iter.Seq[int]: The iterator. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If step is 0.
+func (r *Range) Step(step int) iter.Seq[int] {
+	pkg.Ensure(false, r)
+	pkg.ThrowIf(step == 0, pkg.NewIllegalArgument(errors.New("step must not be 0")))
+
+	lo, hi := r.lo, r.hi
+
+	fn := func(yield func(int) bool) {
+		if lo == hi {
+			return
+		}
+
+		// The distances to the bounds are checked before stepping, so that
+		// the steps never overflow.
+		if step > 0 {
+			for x := lo; ; x += step {
+				if !yield(x) || hi-1-x < step {
+					return
+				}
+			}
+		} else {
+			for x := hi - 1; ; x += step {
+				if !yield(x) || x-lo < -step {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// Compare implements the pkg.Comparer interface.
+//
+// The ranges are ordered by their lower bounds, then by their upper ones.
+// As in Equals, all the empty ranges are equal; they come before the other
+// ranges.
+func (r *Range) Compare(other *Range) int {
+	pkg.Ensure(false, r)
+	pkg.Ensure(false, other)
+
+	switch r_empty, other_empty := r.IsEmpty(), other.IsEmpty(); {
+	case r_empty && other_empty:
+		return 0
+	case r_empty:
+		return -1
+	case other_empty:
+		return 1
+	}
+
+	if c := cmp.Compare(r.lo, other.lo); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(r.hi, other.hi)
+}
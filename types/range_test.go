@@ -0,0 +1,109 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+// collect gathers the integers of an iterator.
+func collect(seq func(yield func(int) bool)) []int {
+	var values []int
+
+	for x := range seq {
+		values = append(values, x)
+	}
+
+	return values
+}
+
+func TestRangeCompareEmpty(t *testing.T) {
+	a, b := NewRange(1, 1), NewRange(2, 2)
+
+	if !a.Equals(b) || a.Compare(b) != 0 {
+		t.Errorf("expected %s and %s to be equal in both Equals and Compare", a, b)
+	}
+
+	if a.Compare(NewRange(0, 1)) != -1 || NewRange(0, 1).Compare(b) != 1 {
+		t.Errorf("expected the empty ranges to come first")
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := NewRange(2, 10)
+
+	if !r.Contains(2) || r.Contains(10) || r.Len() != 8 {
+		t.Fatalf("unexpected bounds for %s", r)
+	}
+
+	if !r.Overlaps(NewRange(9, 12)) || r.Overlaps(NewRange(10, 12)) {
+		t.Errorf("unexpected overlaps for %s", r)
+	}
+
+	if got := r.Intersect(NewRange(5, 20)); got.String() != "[5, 10)" {
+		t.Errorf("expected [5, 10), got %s", got)
+	}
+
+	if !r.Intersect(NewRange(20, 30)).IsEmpty() {
+		t.Errorf("expected an empty intersection")
+	}
+
+	a, b := r.Split(4)
+	if a.String() != "[2, 4)" || b.String() != "[4, 10)" {
+		t.Errorf("unexpected split: %s and %s", a, b)
+	}
+
+	tests := []struct {
+		step int
+		want []int
+	}{
+		{3, []int{2, 5, 8}},
+		{-4, []int{9, 5}},
+	}
+
+	for _, tt := range tests {
+		got := collect(r.Step(tt.step))
+
+		if len(got) != len(tt.want) || got[0] != tt.want[0] || got[len(got)-1] != tt.want[len(tt.want)-1] {
+			t.Errorf("step %d: expected %v, got %v", tt.step, tt.want, got)
+		}
+	}
+
+	if got := collect(NewRange(math.MaxInt-3, math.MaxInt).Step(2)); len(got) != 2 {
+		t.Errorf("expected the steps to stop before overflowing, got %v", got)
+	}
+}
+
+func TestIntervalSet(t *testing.T) {
+	s := NewIntervalSet(NewRange(1, 3), NewRange(10, 12), NewRange(3, 5), NewRange(20, 25))
+
+	if s.String() != "IntervalSet[[1, 5), [10, 12), [20, 25)]" {
+		t.Fatalf("expected merged ranges, got %s", s)
+	}
+
+	s.Add(NewRange(4, 11))
+	if s.String() != "IntervalSet[[1, 12), [20, 25)]" {
+		t.Errorf("expected [1, 12) to absorb the ranges it covers, got %s", s)
+	}
+
+	s.Remove(NewRange(5, 7))
+	s.Difference(NewIntervalSet(NewRange(22, 30)))
+
+	if s.String() != "IntervalSet[[1, 5), [7, 12), [20, 22)]" || s.Len() != 11 {
+		t.Errorf("unexpected difference: %s", s)
+	}
+
+	for x, want := range map[int]bool{0: false, 1: true, 5: false, 7: true, 11: true, 12: false, 21: true} {
+		if s.Contains(x) != want {
+			t.Errorf("expected Contains(%d) to be %t", x, want)
+		}
+	}
+
+	if r, ok := s.Find(8); !ok || r.String() != "[7, 12)" {
+		t.Errorf("expected 8 to be in [7, 12), got %v", r)
+	}
+
+	s.Union(NewIntervalSet(NewRange(5, 7), NewRange(12, 20)))
+	if !s.Equals(NewIntervalSet(NewRange(1, 22))) {
+		t.Errorf("expected the union to fill the gaps, got %s", s)
+	}
+}
@@ -0,0 +1,302 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Ref is a reference-counted handle to a value shared by several owners. Each
+// owner holds one reference: Retain adds one and Release, or Clean, drops
+// one. The value is cleaned when the last reference is dropped.
+//
+// DeepCopy retains the value instead of copying it, so that a container
+// holding a Ref can be copied without duplicating the shared value; Clone
+// makes an independent copy.
+//
+// In debug mode, see pkg.SetDebug, the references still held and the extra
+// releases are recorded; see RefReport and CheckRefs.
+type Ref[T pkg.Type] struct {
+	// value is the shared value.
+	value T
+
+	// count is the number of references.
+	count atomic.Int64
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+}
+
+// ref_tracker tracks the references in debug mode.
+type ref_tracker struct {
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// live maps the refs with references left to the stack of their creation.
+	live map[any]string
+
+	// problems is the list of over-releases seen so far.
+	problems []error
+}
+
+var (
+	// refs is the debug tracker of the references.
+	refs = ref_tracker{
+		live: make(map[any]string),
+	}
+)
+
+// String implements the fmt.Stringer interface.
+func (r *Ref[T]) String() string {
+	r.poison.Check("r")
+
+	return r.value.String()
+}
+
+// DeepCopy implements the pkg.Type interface.
+//
+// The value is not copied: a reference is added and the same ref is returned.
+// Use Clone for a copy.
+func (r *Ref[T]) DeepCopy() pkg.Type {
+	if r == nil {
+		return nil
+	}
+
+	return r.Retain()
+}
+
+// Ensure implements the pkg.Type interface.
+//
+// Throws:
+//   - *InvalidState: If the last reference was released, even outside of the
+//     debug mode.
+func (r *Ref[T]) Ensure() {
+	pkg.ThrowIf(r == nil, pkg.NewInvalidState("r", pkg.NewNilValue()))
+
+	r.poison.Check("r")
+	pkg.ThrowIf(r.count.Load() <= 0, pkg.NewInvalidState("r", pkg.ErrUsedAfterClean))
+}
+
+// Clean implements the pkg.Type interface.
+//
+// It drops one reference, as Release does.
+func (r *Ref[T]) Clean() {
+	if r == nil {
+		return
+	}
+
+	r.Release()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two refs are equal if their values are equal.
+func (r *Ref[T]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, r)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Ref[T]:
+		return r == other || r.value.Equals(other.value)
+	default:
+		return false
+	}
+}
+
+// NewRef creates a new ref holding one reference to a value.
+//
+// Parameters:
+//   - value: The value to share.
+//
+// Returns:
+//   - *Ref[T]: The new ref. Never returns nil.
+//
+// Panics if value is nil.
+func NewRef[T pkg.Type](value T) *Ref[T] {
+	pkg.Ensure(false, value)
+
+	r := &Ref[T]{
+		value: value,
+	}
+
+	r.count.Store(1)
+
+	if pkg.IsDebug() {
+		refs.mu.Lock()
+		refs.live[r] = pkg.CallerStack(1)
+		refs.mu.Unlock()
+	}
+
+	return r
+}
+
+// Value returns the shared value. It must not be cleaned by the caller.
+//
+// Returns:
+//   - T: The shared value.
+func (r *Ref[T]) Value() T {
+	pkg.Ensure(false, r)
+
+	return r.value
+}
+
+// Count returns the number of references.
+//
+// Returns:
+//   - int: The number of references. 0 once the value is cleaned.
+func (r *Ref[T]) Count() int {
+	pkg.ThrowIf(r == nil, pkg.NewInvalidState("r", pkg.NewNilValue()))
+
+	return int(r.count.Load())
+}
+
+// Retain adds a reference.
+//
+// Returns:
+//   - *Ref[T]: The receiver. Never returns nil.
+//
+// Throws:
+//   - *InvalidState: If the value was already cleaned.
+func (r *Ref[T]) Retain() *Ref[T] {
+	pkg.Ensure(false, r)
+
+	for {
+		n := r.count.Load()
+		pkg.ThrowIf(n <= 0, pkg.NewInvalidState("r", pkg.ErrUsedAfterClean))
+
+		if r.count.CompareAndSwap(n, n+1) {
+			return r
+		}
+	}
+}
+
+// Release drops a reference and cleans the value if it was the last one.
+// Extra releases are ignored, and recorded in debug mode.
+//
+// Returns:
+//   - bool: True if the value was cleaned, false otherwise.
+func (r *Ref[T]) Release() bool {
+	pkg.ThrowIf(r == nil, pkg.NewInvalidState("r", pkg.NewNilValue()))
+
+	for {
+		n := r.count.Load()
+
+		if n <= 0 {
+			if pkg.IsDebug() {
+				err := pkg.NewInvalidState("r", fmt.Errorf("ref %p released more times than retained; extra release at:\n%s", r, pkg.CallerStack(1)))
+
+				refs.mu.Lock()
+				refs.problems = append(refs.problems, err)
+				refs.mu.Unlock()
+			}
+
+			return false
+		}
+
+		if r.count.CompareAndSwap(n, n-1) {
+			if n > 1 {
+				return false
+			}
+
+			break
+		}
+	}
+
+	if pkg.IsDebug() {
+		refs.mu.Lock()
+		delete(refs.live, r)
+		refs.mu.Unlock()
+	}
+
+	pkg.Clean(r.value)
+	r.value = *new(T)
+
+	r.poison.Mark()
+
+	return true
+}
+
+// Clone creates a new ref holding a copy of the value.
+//
+// Returns:
+//   - *Ref[T]: The new ref, with one reference. Never returns nil.
+func (r *Ref[T]) Clone() *Ref[T] {
+	pkg.Ensure(false, r)
+
+	return NewRef(pkg.DeepCopy(r.value))
+}
+
+// RefReport returns the problems found by the debug mode: one error per ref
+// that still holds references and one per extra release. See CheckRefs to
+// report them at exit.
+//
+// Returns:
+//   - []error: The problems, as InvalidState errors. Nil if there is no
+//     problem.
+//
+// Only the refs created and released while the debug mode is enabled are
+// tracked.
+func RefReport() []error {
+	refs.mu.Lock()
+	defer refs.mu.Unlock()
+
+	problems := make([]error, 0, len(refs.live)+len(refs.problems))
+	problems = append(problems, refs.problems...)
+
+	for r, stack := range refs.live {
+		err := pkg.NewInvalidState("r", fmt.Errorf("ref %p was never released; created at:\n%s", r, stack))
+		problems = append(problems, err)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return problems
+}
+
+// CheckRefs reports the problems of RefReport at exit, as leaks for the refs
+// that still hold references. It is meant to wrap the exit code of a program
+// or of a test binary:
+//
+//	func TestMain(m *testing.M) {
+//		pkg.SetDebug(true)
+//		os.Exit(types.CheckRefs(m.Run()))
+//	}
+//
+// Parameters:
+//   - code: The exit code.
+//
+// Returns:
+//   - int: The exit code, or 1 if it is 0 and problems were found.
+//
+// The problems are written to the standard error.
+func CheckRefs(code int) int {
+	return check_refs(os.Stderr, code)
+}
+
+// check_refs writes the problems of RefReport and updates the exit code.
+//
+// Parameters:
+//   - w: The writer of the problems.
+//   - code: The exit code.
+//
+// Returns:
+//   - int: The exit code, or 1 if it is 0 and problems were found.
+func check_refs(w io.Writer, code int) int {
+	problems := RefReport()
+
+	for _, err := range problems {
+		fmt.Fprintln(w, err)
+	}
+
+	if len(problems) > 0 && code == 0 {
+		return 1
+	}
+
+	return code
+}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// tracked is a value that counts how many times it was cleaned.
+type tracked struct {
+	*Int
+
+	cleaned *int
+}
+
+func (t *tracked) Clean() {
+	*t.cleaned++
+	t.Int.Clean()
+}
+
+func TestRef(t *testing.T) {
+	var cleaned int
+
+	r := NewRef(&tracked{Int: NewInt().WithValue(7), cleaned: &cleaned})
+
+	a := NewSet[*Ref[*tracked]]().WithValue([]*Ref[*tracked]{r})
+	b := a.DeepCopy()
+
+	if r.Count() != 2 {
+		t.Fatalf("expected the copy to retain the ref, got %d references", r.Count())
+	}
+
+	a.Clean()
+	if cleaned != 0 || r.Value().Value() != 7 {
+		t.Fatalf("expected the value to survive the first Clean")
+	}
+
+	b.Clean()
+	if cleaned != 1 || r.Count() != 0 {
+		t.Errorf("expected the last Clean to clean the value, cleaned %d times", cleaned)
+	}
+
+	if code := code_of(func() { r.Retain() }); code != pkg.InvalidState {
+		t.Errorf("expected InvalidState, got %v", code)
+	}
+}
+
+func TestRefClone(t *testing.T) {
+	r := NewRef(NewInt().WithValue(1))
+	c := r.Clone()
+
+	c.Value().Set(2)
+
+	if r.Value().Value() != 1 || c.Count() != 1 || r.Count() != 1 {
+		t.Errorf("expected an independent copy, got %s and %s", r, c)
+	}
+}
+
+func TestRefReport(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	before := len(RefReport())
+
+	leaked := NewRef(NewInt())
+	over := NewRef(NewInt())
+	over.Release()
+	over.Release()
+
+	if got := len(RefReport()) - before; got != 2 {
+		t.Errorf("expected a leak and an over-release, got %d problems", got)
+	}
+
+	leaked.Release()
+}
+
+func TestCheckRefs(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	leaked := NewRef(NewInt())
+
+	var out strings.Builder
+
+	if code := check_refs(&out, 0); code != 1 {
+		t.Errorf("expected the leak to fail the exit code, got %d", code)
+	}
+
+	if !strings.Contains(out.String(), "never released") {
+		t.Errorf("expected the leak to be written, got %q", out.String())
+	}
+
+	if code := check_refs(io.Discard, 3); code != 3 {
+		t.Errorf("expected the exit code to be kept, got %d", code)
+	}
+
+	leaked.Release()
+}
@@ -0,0 +1,856 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+)
+
+func init() {
+	pkg.Register(nil, "Int", pkg.ScalarKind, nil, NewInt, func(value any) (*Int, error) {
+		x, err := to_int(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewInt().WithValue(x), nil
+	})
+
+	pkg.Register(nil, "Bool", pkg.ScalarKind, nil, NewBool, func(value any) (*Bool, error) {
+		b, ok := value.(bool)
+		if !ok {
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a bool, got %T", value))
+		}
+
+		return NewBool().WithValue(b), nil
+	})
+
+	pkg.Register(nil, "AtomicInt", pkg.ScalarKind, nil, NewAtomicInt, func(value any) (*AtomicInt, error) {
+		x, err := to_int(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewAtomicInt().WithValue(x), nil
+	})
+
+	pkg.Register(nil, "AtomicBool", pkg.ScalarKind, nil, NewAtomicBool, func(value any) (*AtomicBool, error) {
+		b, ok := value.(bool)
+		if !ok {
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a bool, got %T", value))
+		}
+
+		return NewAtomicBool().WithValue(b), nil
+	})
+
+	pkg.Register(nil, "String", pkg.ScalarKind, nil, func() *String { return NewString("") }, func(value any) (*String, error) {
+		str, ok := value.(string)
+		if !ok {
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a string, got %T", value))
+		}
+
+		return NewString(str), nil
+	})
+
+	pkg.Register(nil, "Time", pkg.ScalarKind, nil, func() *Time { return NewTime(time.Time{}) }, func(value any) (*Time, error) {
+		switch value := value.(type) {
+		case time.Time:
+			return NewTime(value), nil
+		case string:
+			return ParseTime(value)
+		default:
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a time.Time or an RFC 3339 string, got %T", value))
+		}
+	})
+
+	pkg.Register(nil, "Date", pkg.ScalarKind, nil, func() *Date { return DateOf(time.Time{}) }, func(value any) (*Date, error) {
+		switch value := value.(type) {
+		case time.Time:
+			return DateOf(value), nil
+		case string:
+			return ParseDate(value)
+		default:
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a time.Time or an RFC 3339 date string, got %T", value))
+		}
+	})
+
+	pkg.Register(nil, "Duration", pkg.ScalarKind, nil, func() *Duration { return NewDuration(0) }, func(value any) (*Duration, error) {
+		switch value := value.(type) {
+		case time.Duration:
+			return NewDuration(value), nil
+		case string:
+			return ParseDuration(value)
+		default:
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a time.Duration or a duration string, got %T", value))
+		}
+	})
+
+	pkg.Register(nil, "BigInt", pkg.ScalarKind, nil, func() *BigInt { return NewBigInt(0) }, func(value any) (*BigInt, error) {
+		switch value := value.(type) {
+		case *big.Int:
+			if value != nil {
+				return BigIntOf(value), nil
+			}
+		case string:
+			return ParseBigInt(value)
+		default:
+			x, err := to_int(value)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewBigInt(int64(x)), nil
+		}
+
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("expected an integer, got %T", value))
+	})
+
+	pkg.Register(nil, "Rat", pkg.ScalarKind, nil, func() *Rat { return NewRat(0, 1) }, func(value any) (*Rat, error) {
+		switch value := value.(type) {
+		case *big.Rat:
+			if value != nil {
+				return RatOf(value), nil
+			}
+		case string:
+			return ParseRat(value)
+		case float32, float64:
+			x, ok := new(big.Rat).SetString(fmt.Sprint(value))
+			if ok {
+				return RatOf(x), nil
+			}
+		default:
+			x, err := to_int(value)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewRat(int64(x), 1), nil
+		}
+
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("%v is not a rational number", value))
+	})
+
+	pkg.Register(nil, "Decimal", pkg.ScalarKind, nil, func() *Decimal { return NewDecimal(0) }, func(value any) (*Decimal, error) {
+		var str string
+
+		switch value := value.(type) {
+		case string:
+			str = value
+		case float32:
+			str = strconv.FormatFloat(float64(value), 'f', -1, 32)
+		case float64:
+			str = strconv.FormatFloat(value, 'f', -1, 64)
+		default:
+			x, err := to_int(value)
+			if err != nil {
+				return nil, err
+			}
+
+			str = strconv.Itoa(x)
+		}
+
+		return ParseDecimal(str, decimal_scale(str))
+	})
+
+	pkg.Register(nil, "Range", pkg.ScalarKind, nil, func() *Range { return NewRange(0, 0) }, to_range)
+
+	int_info := pkg.InfoFor[*Int](nil)
+
+	pkg.Register(nil, "BitSet", pkg.ContainerKind, int_info, func() *BitSet { return NewBitSet() }, func(value any) (*BitSet, error) {
+		elems, err := pkg.MakeElems[*Int](int_info, value)
+		if err != nil {
+			return nil, err
+		}
+
+		b := NewBitSet()
+
+		for _, elem := range elems {
+			if elem.Value() < 0 {
+				return nil, pkg.NewIllegalArgument(fmt.Errorf("%d is negative", elem.Value()))
+			}
+
+			b.Add(elem.Value())
+		}
+
+		return b, nil
+	})
+
+	range_info := pkg.InfoFor[*Range](nil)
+
+	pkg.Register(nil, "IntervalSet", pkg.ContainerKind, range_info, func() *IntervalSet { return NewIntervalSet() }, func(value any) (*IntervalSet, error) {
+		elems, err := pkg.MakeElems[*Range](range_info, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIntervalSet(elems...), nil
+	})
+
+	RegisterNum[int](nil)
+	RegisterNum[float64](nil)
+
+	RegisterWrap[string](nil)
+	RegisterWrap[int](nil)
+	RegisterWrap[float64](nil)
+	RegisterWrap[bool](nil)
+
+	RegisterSet[pkg.Type](nil)
+	RegisterSet[*Int](nil)
+	RegisterSet[*Bool](nil)
+
+	RegisterMultiSet[pkg.Type](nil)
+	RegisterMultiSet[*Int](nil)
+
+	RegisterMap[*String, pkg.Type](nil)
+	RegisterMap[*String, *Int](nil)
+
+	RegisterOrderedMap[*String, pkg.Type](nil)
+	RegisterOrderedMap[*String, *Int](nil)
+
+	slices.RegisterSlice[*Int](nil)
+	slices.RegisterSlice[*Bool](nil)
+}
+
+// to_int converts a plain Go number into an int.
+func to_int(value any) (int, error) {
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x := rv.Int()
+		if int64(int(x)) == x {
+			return int(x), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x := rv.Uint()
+		if x <= math.MaxInt {
+			return int(x), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		x := rv.Float()
+		if x == math.Trunc(x) && x >= math.MinInt && x < math.MaxInt {
+			return int(x), nil
+		}
+	default:
+		return 0, pkg.NewIllegalArgument(fmt.Errorf("expected a number, got %T", value))
+	}
+
+	return 0, pkg.NewIllegalArgument(fmt.Errorf("%v cannot be represented as an int", value))
+}
+
+// to_range converts a plain Go pair of numbers [lo, hi] into a range.
+func to_range(value any) (*Range, error) {
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() != 2 {
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a [lo, hi] pair, got %v", value))
+	}
+
+	lo, err := to_int(rv.Index(0).Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	hi, err := to_int(rv.Index(1).Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	if hi < lo {
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("range upper bound %d is less than its lower bound %d", hi, lo))
+	}
+
+	return NewRange(lo, hi), nil
+}
+
+// decimal_scale returns the number of digits after the decimal point needed
+// to write a decimal number exactly, such as 2 for "1.50" or "125e-4" and 0
+// for "3e2".
+func decimal_scale(str string) int {
+	mantissa, exp, _ := strings.Cut(strings.ToLower(str), "e")
+
+	var scale int
+
+	if _, frac, ok := strings.Cut(mantissa, "."); ok {
+		scale = len(frac)
+	}
+
+	if exp != "" {
+		e, err := strconv.Atoi(exp)
+		if err != nil {
+			return scale
+		}
+
+		scale -= e
+	}
+
+	return max(scale, 0)
+}
+
+// to_value converts a plain Go value into a T. Values of type T are kept and,
+// for numeric types, any number that converts to T without loss is accepted.
+func to_value[T any](value any) (T, error) {
+	rt := reflect.TypeFor[T]()
+
+	v, ok := value.(T)
+	if ok {
+		return v, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.IsValid() && rv.CanConvert(rt) && is_number(rv.Kind()) && is_number(rt.Kind()) {
+		conv := rv.Convert(rt)
+
+		if conv.Convert(rv.Type()).Equal(rv) {
+			return conv.Interface().(T), nil
+		}
+	}
+
+	return *new(T), pkg.NewIllegalArgument(fmt.Errorf("expected a %v, got %T", rt, value))
+}
+
+// RegisterSet registers *Set[T] under the name "Set[{elem}]", where {elem} is
+// the name of T. The factory accepts any Go slice whose elements the factory
+// of T accepts.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Panics if T is not registered in r, unless T is pkg.Type itself.
+func RegisterSet[T pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	elem := pkg.InfoFor[T](r)
+
+	zero := func() *Set[T] {
+		return NewSet[T]()
+	}
+
+	factory := func(value any) (*Set[T], error) {
+		elems, err := pkg.MakeElems[T](elem, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSet[T]().WithValue(elems), nil
+	}
+
+	return pkg.Register(r, "Set["+pkg.NameOf(elem)+"]", pkg.ContainerKind, elem, zero, factory)
+}
+
+// RegisterMultiSet registers *MultiSet[T] under the name "MultiSet[{elem}]",
+// where {elem} is the name of T. The factory accepts any Go slice whose
+// elements the factory of T accepts; repeated elements are counted.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Panics if T is not registered in r, unless T is pkg.Type itself.
+func RegisterMultiSet[T pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	elem := pkg.InfoFor[T](r)
+
+	zero := func() *MultiSet[T] {
+		return NewMultiSet[T]()
+	}
+
+	factory := func(value any) (*MultiSet[T], error) {
+		elems, err := pkg.MakeElems[T](elem, value)
+		if err != nil {
+			return nil, err
+		}
+
+		m := NewMultiSet[T]()
+
+		for _, e := range elems {
+			m.Add(e, 1)
+		}
+
+		return m, nil
+	}
+
+	return pkg.Register(r, "MultiSet["+pkg.NameOf(elem)+"]", pkg.ContainerKind, elem, zero, factory)
+}
+
+// make_entries creates the keys and the values of a map from a plain Go map or
+// from a plain Go slice of [key, value] pairs.
+func make_entries[K, V pkg.Type](key, value *pkg.TypeInfo, entries any) ([]K, []V, error) {
+	rv := reflect.ValueOf(entries)
+
+	var pairs [][2]any
+
+	switch rv.Kind() {
+	case reflect.Map:
+		for it := rv.MapRange(); it.Next(); {
+			pairs = append(pairs, [2]any{it.Key().Interface(), it.Value().Interface()})
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			pair := reflect.ValueOf(rv.Index(i).Interface())
+			if (pair.Kind() != reflect.Slice && pair.Kind() != reflect.Array) || pair.Len() != 2 {
+				return nil, nil, pkg.NewIllegalArgument(fmt.Errorf("expected a [key, value] pair, got %v", rv.Index(i).Interface()))
+			}
+
+			pairs = append(pairs, [2]any{pair.Index(0).Interface(), pair.Index(1).Interface()})
+		}
+	default:
+		return nil, nil, pkg.NewIllegalArgument(fmt.Errorf("expected a map or a slice of pairs, got %T", entries))
+	}
+
+	keys := make([]K, 0, len(pairs))
+	values := make([]V, 0, len(pairs))
+
+	for _, pair := range pairs {
+		k, err := pkg.MakeElem[K](key, pair[0])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		v, err := pkg.MakeElem[V](value, pair[1])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	return keys, values, nil
+}
+
+// RegisterMap registers *Map[K, V] under the name "Map[{K}, {V}]", where {K}
+// and {V} are the names of K and V. The element type of the registered type
+// is V. The factory accepts any Go map, or any Go slice of [key, value] pairs,
+// whose keys and values the factories of K and V accept.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Panics if K or V is not registered in r, unless it is pkg.Type itself.
+func RegisterMap[K, V pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	key := pkg.InfoFor[K](r)
+	elem := pkg.InfoFor[V](r)
+
+	zero := func() *Map[K, V] {
+		return NewMap[K, V]()
+	}
+
+	factory := func(value any) (*Map[K, V], error) {
+		keys, values, err := make_entries[K, V](key, elem, value)
+		if err != nil {
+			return nil, err
+		}
+
+		m := NewMap[K, V]()
+
+		for i, k := range keys {
+			m.Put(k, values[i])
+		}
+
+		return m, nil
+	}
+
+	return pkg.Register(r, "Map["+pkg.NameOf(key)+", "+pkg.NameOf(elem)+"]", pkg.ContainerKind, elem, zero, factory)
+}
+
+// RegisterOrderedMap registers *OrderedMap[K, V] under the name
+// "OrderedMap[{K}, {V}]", where {K} and {V} are the names of K and V. The
+// element type of the registered type is V. The factory accepts any Go slice
+// of [key, value] pairs whose keys and values the factories of K and V accept;
+// Go maps are rejected since they have no order.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Panics if K or V is not registered in r, unless it is pkg.Type itself.
+func RegisterOrderedMap[K, V pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	key := pkg.InfoFor[K](r)
+	elem := pkg.InfoFor[V](r)
+
+	zero := func() *OrderedMap[K, V] {
+		return NewOrderedMap[K, V]()
+	}
+
+	factory := func(value any) (*OrderedMap[K, V], error) {
+		if reflect.ValueOf(value).Kind() == reflect.Map {
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a slice of pairs, got %T", value))
+		}
+
+		keys, values, err := make_entries[K, V](key, elem, value)
+		if err != nil {
+			return nil, err
+		}
+
+		m := NewOrderedMap[K, V]()
+
+		for i, k := range keys {
+			m.Put(k, values[i])
+		}
+
+		return m, nil
+	}
+
+	return pkg.Register(r, "OrderedMap["+pkg.NameOf(key)+", "+pkg.NameOf(elem)+"]", pkg.ContainerKind, elem, zero, factory)
+}
+
+// to_tuple converts a plain Go slice of n values into a []any.
+func to_tuple(value any, n int) ([]any, error) {
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() != n {
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a slice of %d values, got %v", n, value))
+	}
+
+	values := make([]any, 0, n)
+
+	for i := 0; i < n; i++ {
+		values = append(values, rv.Index(i).Interface())
+	}
+
+	return values, nil
+}
+
+// concrete_info returns the type info of the value of a tuple or a ref.
+//
+// Throws:
+//   - *IllegalArgument: If T is pkg.Type itself, since it has no zero value.
+func concrete_info[T pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	info := pkg.InfoFor[T](r)
+	pkg.ThrowIf(info == nil, pkg.NewIllegalArgument(fmt.Errorf("expected a registered type, got %s", pkg.AnyName)))
+
+	return info
+}
+
+// RegisterPair registers *Pair[A, B] under the name "Pair[{A}, {B}]", where
+// {A} and {B} are the names of A and B. The factory accepts any Go slice of
+// two values that the factories of A and B accept.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If A or B is pkg.Type itself.
+//
+// Panics if A or B is not registered in r.
+func RegisterPair[A, B pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	first := concrete_info[A](r)
+	second := concrete_info[B](r)
+
+	zero := func() *Pair[A, B] {
+		return NewPair(first.Zero().(A), second.Zero().(B))
+	}
+
+	factory := func(value any) (*Pair[A, B], error) {
+		values, err := to_tuple(value, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		a, err := pkg.MakeElem[A](first, values[0])
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := pkg.MakeElem[B](second, values[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return NewPair(a, b), nil
+	}
+
+	return pkg.Register(r, "Pair["+first.Name+", "+second.Name+"]", pkg.ScalarKind, nil, zero, factory)
+}
+
+// RegisterTriple registers *Triple[A, B, C] under the name
+// "Triple[{A}, {B}, {C}]", where {A}, {B} and {C} are the names of A, B and C.
+// The factory accepts any Go slice of three values that the factories of A, B
+// and C accept.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If A, B or C is pkg.Type itself.
+//
+// Panics if A, B or C is not registered in r.
+func RegisterTriple[A, B, C pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	first := concrete_info[A](r)
+	second := concrete_info[B](r)
+	third := concrete_info[C](r)
+
+	zero := func() *Triple[A, B, C] {
+		return NewTriple(first.Zero().(A), second.Zero().(B), third.Zero().(C))
+	}
+
+	factory := func(value any) (*Triple[A, B, C], error) {
+		values, err := to_tuple(value, 3)
+		if err != nil {
+			return nil, err
+		}
+
+		a, err := pkg.MakeElem[A](first, values[0])
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := pkg.MakeElem[B](second, values[1])
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := pkg.MakeElem[C](third, values[2])
+		if err != nil {
+			return nil, err
+		}
+
+		return NewTriple(a, b, c), nil
+	}
+
+	return pkg.Register(r, "Triple["+first.Name+", "+second.Name+", "+third.Name+"]", pkg.ScalarKind, nil, zero, factory)
+}
+
+// RegisterRef registers *Ref[T] under the name "Ref[{T}]", where {T} is the
+// name of T. The factory accepts any value that the factory of T accepts and
+// returns a new ref holding one reference to it.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If T is pkg.Type itself.
+//
+// Panics if T is not registered in r.
+func RegisterRef[T pkg.Type](r *pkg.Registry) *pkg.TypeInfo {
+	elem := concrete_info[T](r)
+
+	zero := func() *Ref[T] {
+		return NewRef(elem.Zero().(T))
+	}
+
+	factory := func(value any) (*Ref[T], error) {
+		v, err := pkg.MakeElem[T](elem, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewRef(v), nil
+	}
+
+	return pkg.Register(r, "Ref["+elem.Name+"]", pkg.ContainerKind, elem, zero, factory)
+}
+
+// RegisterWrap registers *Wrap[T] under the name "Wrap[{T}]", where {T} is the
+// Go name of T. The factory accepts values of type T and, for numeric types,
+// any number that converts to T without loss.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+func RegisterWrap[T comparable](r *pkg.Registry) *pkg.TypeInfo {
+	zero := func() *Wrap[T] {
+		return NewWrap(*new(T))
+	}
+
+	factory := func(value any) (*Wrap[T], error) {
+		v, err := to_value[T](value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewWrap(v), nil
+	}
+
+	return pkg.Register(r, "Wrap["+reflect.TypeFor[T]().String()+"]", pkg.ScalarKind, nil, zero, factory)
+}
+
+// RegisterWrapFunc registers *WrapFunc[T] under the given name. Since the
+// functions of a wrap cannot be recovered from a plain value, they are given
+// here and every value the registry creates uses them. No wrap is registered
+// by default. The factory accepts values of type T and, for numeric types,
+// any number that converts to T without loss.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//   - name: The name of the type.
+//   - fns: The functions of the created wraps.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+func RegisterWrapFunc[T any](r *pkg.Registry, name string, fns WrapFuncs[T]) *pkg.TypeInfo {
+	zero := func() *WrapFunc[T] {
+		return NewWrapFunc(*new(T), fns)
+	}
+
+	factory := func(value any) (*WrapFunc[T], error) {
+		v, err := to_value[T](value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewWrapFunc(v, fns), nil
+	}
+
+	return pkg.Register(r, name, pkg.ScalarKind, nil, zero, factory)
+}
+
+// RegisterNum registers *Num[T] under the name "Num[{T}]", where {T} is the Go
+// name of T. The factory accepts values of type T and any number that
+// converts to T without loss.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+func RegisterNum[T Number](r *pkg.Registry) *pkg.TypeInfo {
+	zero := func() *Num[T] {
+		return NewNum(*new(T))
+	}
+
+	factory := func(value any) (*Num[T], error) {
+		v, err := to_value[T](value)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewNum(v), nil
+	}
+
+	return pkg.Register(r, "Num["+reflect.TypeFor[T]().String()+"]", pkg.ScalarKind, nil, zero, factory)
+}
+
+// RegisterEnum registers *Enum[T] under the given name. The factory accepts
+// the literal representation of one of the given values or any number that
+// converts to one of them. The values are registered as the value set of T,
+// as with RegisterEnumValues.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//   - name: The name of the type.
+//   - values: The valid values. The zero enum holds the first one.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If values is empty, or if two values are equal or have
+//     the same name.
+func RegisterEnum[T Enumer](r *pkg.Registry, name string, values ...T) *pkg.TypeInfo {
+	RegisterEnumValues(values...)
+
+	zero := func() *Enum[T] {
+		return NewEnum(values[0])
+	}
+
+	factory := func(value any) (*Enum[T], error) {
+		for _, v := range values {
+			if str, ok := value.(string); ok && v.String() == str {
+				return NewEnum(v), nil
+			}
+		}
+
+		x, err := to_int(value)
+		if err == nil {
+			for _, v := range values {
+				if int(v) == x {
+					return NewEnum(v), nil
+				}
+			}
+		}
+
+		return nil, pkg.NewIllegalArgument(fmt.Errorf("%v is not a valid %s", value, name))
+	}
+
+	return pkg.Register(r, name, pkg.ScalarKind, nil, zero, factory)
+}
+
+// RegisterFlags registers *Flags[T] under the given name. The factory accepts
+// the "A|B|C" form of ParseFlags, or any Go slice of flags, each of which is
+// either the name or the number of a registered value. No flag set is
+// registered by default, since no Enumer type is built in.
+//
+// Parameters:
+//   - r: The registry. If nil, pkg.DefaultRegistry is used.
+//   - name: The name of the type.
+//
+// Returns:
+//   - *pkg.TypeInfo: The registered type info. Never returns nil.
+func RegisterFlags[T Enumer](r *pkg.Registry, name string) *pkg.TypeInfo {
+	zero := func() *Flags[T] {
+		return NewFlags[T]()
+	}
+
+	factory := func(value any) (*Flags[T], error) {
+		if str, ok := value.(string); ok {
+			return ParseFlags[T](str)
+		}
+
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, pkg.NewIllegalArgument(fmt.Errorf("expected a string or a slice of flags, got %T", value))
+		}
+
+		flags := make([]T, 0, rv.Len())
+
+		for i := 0; i < rv.Len(); i++ {
+			elem := rv.Index(i).Interface()
+
+			if str, ok := elem.(string); ok {
+				flag, err := enum_by_name[T](str)
+				if err != nil {
+					return nil, err
+				}
+
+				flags = append(flags, flag)
+
+				continue
+			}
+
+			x, err := to_int(elem)
+			if err != nil {
+				return nil, err
+			}
+
+			if !IsEnumValue(T(x)) {
+				return nil, pkg.NewIllegalArgument(fmt.Errorf("%v is not a valid %s flag", elem, name))
+			}
+
+			flags = append(flags, T(x))
+		}
+
+		return NewFlags(flags...), nil
+	}
+
+	return pkg.Register(r, name, pkg.ScalarKind, nil, zero, factory)
+}
+
+// is_number checks whether the kind is a numeric kind.
+func is_number(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
@@ -0,0 +1,245 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+)
+
+func TestRegistry(t *testing.T) {
+	v, err := pkg.DefaultRegistry.Make("Slice[Bool]", []any{true, false})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := slices.NewSlice[*Bool]().WithValue([]*Bool{NewBool().WithValue(true), NewBool()})
+	if !want.Equals(v) {
+		t.Errorf("expected %s, got %s", want, v)
+	}
+
+	name, ok := pkg.DefaultRegistry.TypeName(v)
+	if !ok || name != "Slice[Bool]" {
+		t.Errorf("expected %q, got %q", "Slice[Bool]", name)
+	}
+
+	zero, err := pkg.DefaultRegistry.New("Int")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !zero.Equals(NewInt()) {
+		t.Errorf("expected 0, got %s", zero)
+	}
+
+	_, err = pkg.DefaultRegistry.Make("Int", 1.5)
+	if err == nil {
+		t.Errorf("expected an error for a non-integral number")
+	}
+}
+
+func TestRegistryNumbers(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"AtomicInt", 3.0, "3"},
+		{"AtomicBool", true, "T"},
+		{"BigInt", "123456789012345678901234567890", "123456789012345678901234567890"},
+		{"BigInt", 42.0, "42"},
+		{"Rat", "3/6", "1/2"},
+		{"Rat", 0.25, "1/4"},
+		{"Decimal", "-1.50", "-1.50"},
+		{"Decimal", "125e-4", "0.0125"},
+		{"Decimal", 2.5, "2.5"},
+		{"Num[int]", 7.0, "7"},
+		{"Num[float64]", 1.5, "1.5"},
+		{"Range", []any{1.0, 4.0}, "[1, 4)"},
+		{"BitSet", []any{3.0, 1.0}, "BitSet[1, 3]"},
+		{"IntervalSet", []any{[]any{5.0, 9.0}, []any{1.0, 3.0}}, "IntervalSet[[1, 3), [5, 9)]"},
+	}
+
+	for _, tt := range tests {
+		v, err := pkg.DefaultRegistry.Make(tt.name, tt.value)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+
+		if v.String() != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, v)
+		}
+
+		name, ok := pkg.DefaultRegistry.TypeName(v)
+		if !ok || name != tt.name {
+			t.Errorf("expected %q, got %q", tt.name, name)
+		}
+	}
+
+	invalid := []struct {
+		name  string
+		value any
+	}{
+		{"AtomicInt", 1.5},
+		{"AtomicBool", 1.0},
+		{"BigInt", 1.5},
+		{"Decimal", "abc"},
+		{"Num[int]", 1.5},
+		{"Range", []any{4.0, 1.0}},
+		{"BitSet", []any{-1.0}},
+	}
+
+	for _, tt := range invalid {
+		if _, err := pkg.DefaultRegistry.Make(tt.name, tt.value); err == nil {
+			t.Errorf("%s: expected an error for %v", tt.name, tt.value)
+		}
+	}
+}
+
+func TestRegistryMap(t *testing.T) {
+	want := NewMap[*String, *Int]()
+	want.Put(NewString("a"), NewInt().WithValue(1))
+
+	for _, value := range []any{map[string]any{"a": 1.0}, []any{[]any{"a", 1.0}}} {
+		v, err := pkg.DefaultRegistry.Make("Map[String, Int]", value)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !want.Equals(v) {
+			t.Errorf("expected %s, got %s", want, v)
+		}
+	}
+
+	if _, err := pkg.DefaultRegistry.Make("Map[String, Int]", []any{"a"}); err == nil {
+		t.Errorf("expected an error for an entry that is not a pair")
+	}
+}
+
+func TestRegistryOrderedMap(t *testing.T) {
+	v, err := pkg.DefaultRegistry.Make("OrderedMap[String, Int]", []any{[]any{"b", 2.0}, []any{"a", 1.0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v.String() != "OrderedMap[b: 2, a: 1]" {
+		t.Errorf("expected %s, got %s", "OrderedMap[b: 2, a: 1]", v)
+	}
+
+	if _, err := pkg.DefaultRegistry.Make("OrderedMap[String, Int]", map[string]any{"a": 1.0}); err == nil {
+		t.Errorf("expected an error for an unordered map")
+	}
+}
+
+func TestRegistryMultiSet(t *testing.T) {
+	v, err := pkg.DefaultRegistry.Make("MultiSet[Int]", []any{1.0, 3.0, 1.0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := v.(*MultiSet[*Int])
+
+	if m.Count(NewInt().WithValue(1)) != 2 || m.Count(NewInt().WithValue(3)) != 1 {
+		t.Errorf("expected counts 2 and 1, got %s", m)
+	}
+}
+
+func TestRegistryFlags(t *testing.T) {
+	r := pkg.NewRegistry()
+	RegisterFlags[perm](r, "Perm")
+
+	for _, value := range []any{"EXEC|READ", []any{"READ", 4.0}} {
+		v, err := r.Make("Perm", value)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if v.String() != "READ|EXEC" {
+			t.Errorf("expected %s, got %s", "READ|EXEC", v)
+		}
+	}
+
+	if _, err := r.Make("Perm", []any{3.0}); err == nil {
+		t.Errorf("expected an error for a value that is not a flag")
+	}
+}
+
+func TestRegistryTuples(t *testing.T) {
+	r := pkg.NewRegistry()
+	RegisterWrap[int](r)
+	RegisterWrap[string](r)
+
+	RegisterPair[*Wrap[int], *Wrap[string]](r)
+	RegisterTriple[*Wrap[int], *Wrap[int], *Wrap[string]](r)
+
+	tests := []struct {
+		name  string
+		value any
+		want  pkg.Type
+	}{
+		{"Pair[Wrap[int], Wrap[string]]", []any{1.0, "a"}, NewPair(NewWrap(1), NewWrap("a"))},
+		{"Triple[Wrap[int], Wrap[int], Wrap[string]]", []any{1.0, 2.0, "a"}, NewTriple(NewWrap(1), NewWrap(2), NewWrap("a"))},
+	}
+
+	for _, tt := range tests {
+		v, err := r.Make(tt.name, tt.value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		if !tt.want.Equals(v) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, v)
+		}
+
+		if _, err := r.New(tt.name); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+	}
+
+	if _, err := r.Make("Pair[Wrap[int], Wrap[string]]", []any{1.0}); err == nil {
+		t.Errorf("expected an error for a slice of one value")
+	}
+
+	if code := code_of(func() { RegisterPair[pkg.Type, *Wrap[int]](r) }); code != pkg.IllegalArgument {
+		t.Errorf("expected %v, got %v", pkg.IllegalArgument, code)
+	}
+}
+
+func TestRegistryRef(t *testing.T) {
+	r := pkg.NewRegistry()
+	RegisterWrap[int](r)
+	RegisterRef[*Wrap[int]](r)
+
+	v, err := r.Make("Ref[Wrap[int]]", 3.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ref := v.(*Ref[*Wrap[int]])
+	defer ref.Release()
+
+	if ref.Count() != 1 || !ref.Value().Equals(NewWrap(3)) {
+		t.Errorf("expected one reference to 3, got %d references to %s", ref.Count(), ref)
+	}
+}
+
+func TestRegistryWrapFunc(t *testing.T) {
+	r := pkg.NewRegistry()
+	RegisterWrapFunc(r, "Bytes", WrapFuncs[[]byte]{
+		Format: func(value []byte) string { return string(value) },
+	})
+
+	v, err := r.Make("Bytes", []byte("abc"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v.String() != "abc" {
+		t.Errorf("expected %s, got %s", "abc", v)
+	}
+
+	if _, err := r.Make("Bytes", "abc"); err == nil {
+		t.Errorf("expected an error for a value of another type")
+	}
+}
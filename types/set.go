@@ -5,6 +5,7 @@ import (
 	"iter"
 	"strings"
 
+	"github.com/PlayerR9/GoSD/event"
 	"github.com/PlayerR9/GoSD/pkg"
 )
 
@@ -12,10 +13,22 @@ import (
 type Set[T pkg.Type] struct {
 	// values is the set values.
 	values []T
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
 }
 
 // String implements the fmt.Stringer interface.
 func (s *Set[T]) String() string {
+	s.poison.Check("s")
+
 	var builder strings.Builder
 
 	builder.WriteString("Set[")
@@ -37,6 +50,8 @@ func (s *Set[T]) DeepCopy() pkg.Type {
 		return nil
 	}
 
+	s.poison.Check("s")
+
 	slice := make([]T, 0, len(s.values))
 
 	for _, v := range s.values {
@@ -56,6 +71,8 @@ func (s *Set[T]) DeepCopy() pkg.Type {
 // Ensure implements the pkg.Type interface.
 func (s *Set[T]) Ensure() {
 	pkg.ThrowIf(s == nil, pkg.NewInvalidState("s", pkg.NewNilValue()))
+
+	s.poison.Check("s")
 }
 
 // Clean implements the pkg.Type interface.
@@ -64,13 +81,19 @@ func (s *Set[T]) Clean() {
 		return
 	}
 
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
 	s.values = pkg.CleanSlice(s.values)
 	s.values = nil
+
+	s.poison.Mark()
+	s.observers = nil
 }
 
 // Equals implements the pkg.Type interface.
 //
-// Two sets are equal if they have the same values.
+// Two sets are equal if they have the same elements, regardless of their
+// order.
 func (s *Set[T]) Equals(other pkg.Type) bool {
 	pkg.Ensure(false, s)
 	pkg.Ensure(false, other)
@@ -81,8 +104,8 @@ func (s *Set[T]) Equals(other pkg.Type) bool {
 			return false
 		}
 
-		for i := 0; i < len(s.values); i++ {
-			if !s.values[i].Equals(other.values[i]) {
+		for _, elem := range s.values {
+			if !other.Has(elem) {
 				return false
 			}
 		}
@@ -125,9 +148,23 @@ func (s *Set[T]) WithValue(slice []T) *Set[T] {
 		}
 	}
 
+	s.poison.Check("s")
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	var old []T
+
+	if s.observers != nil {
+		// The old elements are cleaned below; the observers get copies.
+		old = s.DeepCopy().(*Set[T]).values
+	}
+
 	s.values = pkg.CleanSlice(s.values)
 	s.values = unique
 
+	if s.observers != nil {
+		s.observers.Notify(event.Reset[T]{Old: old, New: s.values})
+	}
+
 	return s
 }
 
@@ -136,6 +173,8 @@ func (s *Set[T]) WithValue(slice []T) *Set[T] {
 // Returns:
 //   - bool: True if the set is empty, false otherwise.
 func (s Set[T]) IsEmpty() bool {
+	s.poison.Check("s")
+
 	return len(s.values) == 0
 }
 
@@ -144,6 +183,8 @@ func (s Set[T]) IsEmpty() bool {
 // Returns:
 //   - int: The number of elements in the set.
 func (s Set[T]) Size() int {
+	s.poison.Check("s")
+
 	return len(s.values)
 }
 
@@ -156,13 +197,20 @@ func (s Set[T]) Size() int {
 //   - bool: True if the element was added, false otherwise.
 func (s *Set[T]) Add(elem T) bool {
 	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
 
 	has := pkg.Contains(s.values, elem)
-	if !has {
-		s.values = append(s.values, elem)
+	if has {
+		return false
+	}
+
+	s.values = append(s.values, elem)
+
+	if s.observers != nil {
+		s.observers.Notify(event.Added[T]{Elem: elem})
 	}
 
-	return !has
+	return true
 }
 
 // Union adds all elements from another set to the set.
@@ -174,6 +222,7 @@ func (s *Set[T]) Add(elem T) bool {
 //   - int: The number of elements added.
 func (s *Set[T]) Union(other *Set[T]) int {
 	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
 
 	if other == nil {
 		return 0
@@ -181,13 +230,21 @@ func (s *Set[T]) Union(other *Set[T]) int {
 
 	var count int
 
-	for i := 0; i < len(other.values); i++ {
-		ok := pkg.Contains(s.values, other.values[i])
-		if !ok {
+	s.observers.Batch(func() {
+		for i := 0; i < len(other.values); i++ {
+			ok := pkg.Contains(s.values, other.values[i])
+			if ok {
+				continue
+			}
+
 			s.values = append(s.values, other.values[i])
 			count++
+
+			if s.observers != nil {
+				s.observers.Notify(event.Added[T]{Elem: other.values[i]})
+			}
 		}
-	}
+	})
 
 	return count
 }
@@ -198,10 +255,29 @@ func (s *Set[T]) Reset() {
 		return
 	}
 
+	s.poison.Check("s")
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	var old []T
+
+	if s.observers != nil {
+		old = append(old, s.values...)
+	}
+
 	for i := 0; i < len(s.values); i++ {
 		s.values[i] = *new(T)
 	}
 	s.values = s.values[:0]
+
+	if s.observers != nil {
+		s.observers.Notify(event.Reset[T]{Old: old})
+	}
+}
+
+// Recycle implements the pkg.Recycler interface.
+func (s *Set[T]) Recycle(key *pkg.RecycleKey) {
+	s.poison.Revive(key)
+	s.Reset()
 }
 
 // Each returns an iterator that iterates over all elements in the set.
@@ -209,6 +285,8 @@ func (s *Set[T]) Reset() {
 // Returns:
 //   - iter.Seq[T]: The iterator. Never returns nil.
 func (s Set[T]) Each() iter.Seq[T] {
+	s.poison.Check("s")
+
 	fn := func(yield func(T) bool) {
 		for _, elem := range s.values {
 			if !yield(elem) {
@@ -228,6 +306,8 @@ func (s Set[T]) Each() iter.Seq[T] {
 // Returns:
 //   - bool: True if the set contains the element, false otherwise.
 func (s Set[T]) Has(elem T) bool {
+	s.poison.Check("s")
+
 	for i := 0; i < len(s.values); i++ {
 		if s.values[i].Equals(elem) {
 			return true
@@ -236,3 +316,151 @@ func (s Set[T]) Has(elem T) bool {
 
 	return false
 }
+
+// Freeze implements the pkg.Freezer interface.
+//
+// The elements that implement pkg.Freezer are frozen as well.
+func (s *Set[T]) Freeze() {
+	pkg.Ensure(false, s)
+
+	if s.frozen {
+		return
+	}
+
+	s.frozen = true
+
+	for _, elem := range s.values {
+		pkg.Freeze(elem)
+	}
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (s Set[T]) IsFrozen() bool {
+	return s.frozen
+}
+
+// Subscribe subscribes to the changes of the set.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Added[T], event.Removed[T] and
+//     event.Reset[T] changes, synchronously after the mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (s *Set[T]) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, s)
+
+	if s.observers == nil {
+		s.observers = &event.Observers{}
+	}
+
+	return s.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the set into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (s *Set[T]) Batch(fn func()) {
+	pkg.Ensure(false, s)
+
+	s.observers.Batch(fn)
+}
+
+// remove removes the element at a position, keeping the order of the others,
+// and notifies it as removed.
+//
+// Parameters:
+//   - i: The position. It must be in [0, len(s.values)).
+//
+// Returns:
+//   - T: The removed element.
+func (s *Set[T]) remove(i int) T {
+	removed := s.values[i]
+
+	copy(s.values[i:], s.values[i+1:])
+	s.values[len(s.values)-1] = *new(T)
+	s.values = s.values[:len(s.values)-1]
+
+	if s.observers != nil {
+		s.observers.Notify(event.Removed[T]{Index: -1, Elem: removed})
+	}
+
+	return removed
+}
+
+// Remove removes an element from the set. The removed element is not
+// cleaned.
+//
+// Parameters:
+//   - elem: The element to remove.
+//
+// Returns:
+//   - T: The removed element, as stored in the set. The zero value if the
+//     element was not in the set.
+//   - bool: True if the element was in the set, false otherwise.
+func (s *Set[T]) Remove(elem T) (T, bool) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	i := pkg.IndexOf(s.values, elem)
+	if i < 0 {
+		return *new(T), false
+	}
+
+	return s.remove(i), true
+}
+
+// Pop removes the most recently added element from the set. The removed
+// element is not cleaned.
+//
+// Returns:
+//   - T: The removed element. The zero value if the set is empty.
+//   - bool: True if an element was removed, false if the set is empty.
+func (s *Set[T]) Pop() (T, bool) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	if len(s.values) == 0 {
+		return *new(T), false
+	}
+
+	return s.remove(len(s.values) - 1), true
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Added[T], event.Removed[T] or event.Reset[T] of the set. Reverting a
+// reset does not clean the current elements; they are handed back in the
+// returned change.
+func (s *Set[T]) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	switch c := c.(type) {
+	case event.Added[T]:
+		elem, ok := s.Remove(c.Elem)
+		pkg.ThrowIf(!ok, event.NewNotRevertible(c, s))
+
+		return event.Removed[T]{Index: -1, Elem: elem}
+	case event.Removed[T]:
+		ok := s.Add(c.Elem)
+		pkg.ThrowIf(!ok, event.NewNotRevertible(c, s))
+
+		return event.Added[T]{Elem: c.Elem}
+	case event.Reset[T]:
+		inverse := event.Reset[T]{Old: s.values, New: c.Old}
+
+		s.values = append(make([]T, 0, len(c.Old)), c.Old...)
+
+		if s.observers != nil {
+			s.observers.Notify(inverse)
+		}
+
+		return inverse
+	default:
+		panic(event.NewNotRevertible(c, s))
+	}
+}
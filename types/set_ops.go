@@ -0,0 +1,334 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/PlayerR9/GoSD/event"
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// MaxPowerSetSize is the largest set PowerSet accepts, as the power set of n
+// elements has 2^n subsets.
+const MaxPowerSetSize int = 20
+
+// add appends an element that is not in the set yet and notifies it as added.
+//
+// Parameters:
+//   - elem: The element.
+func (s *Set[T]) add(elem T) {
+	s.values = append(s.values, elem)
+
+	if s.observers != nil {
+		s.observers.Notify(event.Added[T]{Elem: elem})
+	}
+}
+
+// Retain removes the elements that do not satisfy a predicate. The removed
+// elements are not cleaned.
+//
+// Parameters:
+//   - pred: The predicate.
+//
+// Returns:
+//   - int: The number of elements removed.
+//
+// Panics if pred is nil.
+func (s *Set[T]) Retain(pred func(elem T) bool) int {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+	pkg.ThrowIf(pred == nil, pkg.NewInvalidCall("pred", pkg.NewNilValue()))
+
+	var count int
+
+	s.observers.Batch(func() {
+		for i := len(s.values) - 1; i >= 0; i-- {
+			if pred(s.values[i]) {
+				continue
+			}
+
+			s.remove(i)
+			count++
+		}
+	})
+
+	return count
+}
+
+// Filter returns a new set with deep copies of the elements that satisfy a
+// predicate. It is the copying form of Retain.
+//
+// Parameters:
+//   - pred: The predicate.
+//
+// Returns:
+//   - *Set[T]: The new set. Never returns nil.
+//
+// Panics if pred is nil.
+func (s *Set[T]) Filter(pred func(elem T) bool) *Set[T] {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(pred == nil, pkg.NewInvalidCall("pred", pkg.NewNilValue()))
+
+	result := NewSet[T]()
+
+	for _, elem := range s.values {
+		if pred(elem) {
+			result.values = append(result.values, pkg.DeepCopy(elem))
+		}
+	}
+
+	return result
+}
+
+// Intersection removes the elements that are not in other. The removed
+// elements are not cleaned.
+//
+// Parameters:
+//   - other: The other set. If nil, it is treated as empty.
+//
+// Returns:
+//   - int: The number of elements removed.
+func (s *Set[T]) Intersection(other *Set[T]) int {
+	return s.Retain(func(elem T) bool {
+		return other != nil && other.Has(elem)
+	})
+}
+
+// Difference removes the elements that are in other. The removed elements are
+// not cleaned.
+//
+// Parameters:
+//   - other: The other set. If nil, it is treated as empty.
+//
+// Returns:
+//   - int: The number of elements removed.
+func (s *Set[T]) Difference(other *Set[T]) int {
+	return s.Retain(func(elem T) bool {
+		return other == nil || !other.Has(elem)
+	})
+}
+
+// SymmetricDifference removes the elements that are in other and adds the
+// elements of other that are not in the set. As with Union, the added
+// elements are shared with other; the removed ones are not cleaned.
+//
+// Parameters:
+//   - other: The other set. If nil, it is treated as empty.
+//
+// Returns:
+//   - int: The number of elements added or removed.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) int {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	if other == nil {
+		return 0
+	}
+
+	// Copy in case other is s.
+	others := append([]T(nil), other.values...)
+
+	var count int
+
+	s.observers.Batch(func() {
+		for _, elem := range others {
+			if i := pkg.IndexOf(s.values, elem); i >= 0 {
+				s.remove(i)
+			} else {
+				s.add(elem)
+			}
+
+			count++
+		}
+	})
+
+	return count
+}
+
+// IsSubset checks whether every element of the set is in other.
+//
+// Parameters:
+//   - other: The other set. If nil, it is treated as empty.
+//
+// Returns:
+//   - bool: True if the set is a subset of other, false otherwise.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	pkg.Ensure(false, s)
+
+	if other == nil {
+		return len(s.values) == 0
+	}
+
+	if len(s.values) > len(other.values) {
+		return false
+	}
+
+	for _, elem := range s.values {
+		if !other.Has(elem) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSuperset checks whether every element of other is in the set.
+//
+// Parameters:
+//   - other: The other set. If nil, it is treated as empty.
+//
+// Returns:
+//   - bool: True if the set is a superset of other, false otherwise.
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	pkg.Ensure(false, s)
+
+	return other == nil || other.IsSubset(s)
+}
+
+// IsDisjoint checks whether the set and other have no element in common.
+//
+// Parameters:
+//   - other: The other set. If nil, it is treated as empty.
+//
+// Returns:
+//   - bool: True if the sets are disjoint, false otherwise.
+func (s *Set[T]) IsDisjoint(other *Set[T]) bool {
+	pkg.Ensure(false, s)
+
+	if other == nil {
+		return true
+	}
+
+	for _, elem := range s.values {
+		if other.Has(elem) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// copy_set returns a deep copy of a set.
+//
+// Parameters:
+//   - s: The set. If nil, an empty set is returned.
+//
+// Returns:
+//   - *Set[T]: The copy. Never returns nil.
+func copy_set[T pkg.Type](s *Set[T]) *Set[T] {
+	if s == nil {
+		return NewSet[T]()
+	}
+
+	return pkg.DeepCopy(s)
+}
+
+// UnionOf returns a new set with deep copies of the elements that are in a or
+// b. It is the copying form of Union.
+//
+// Parameters:
+//   - a: The first set. If nil, it is treated as empty.
+//   - b: The second set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *Set[T]: The new set. Never returns nil.
+func UnionOf[T pkg.Type](a, b *Set[T]) *Set[T] {
+	result := copy_set(a)
+	result.Union(copy_set(b))
+
+	return result
+}
+
+// IntersectionOf returns a new set with deep copies of the elements that are
+// in both a and b. It is the copying form of Intersection.
+//
+// Parameters:
+//   - a: The first set. If nil, it is treated as empty.
+//   - b: The second set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *Set[T]: The new set. Never returns nil.
+func IntersectionOf[T pkg.Type](a, b *Set[T]) *Set[T] {
+	if a == nil {
+		return NewSet[T]()
+	}
+
+	return a.Filter(func(elem T) bool {
+		return b != nil && b.Has(elem)
+	})
+}
+
+// DifferenceOf returns a new set with deep copies of the elements that are in
+// a but not in b. It is the copying form of Difference.
+//
+// Parameters:
+//   - a: The first set. If nil, it is treated as empty.
+//   - b: The second set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *Set[T]: The new set. Never returns nil.
+func DifferenceOf[T pkg.Type](a, b *Set[T]) *Set[T] {
+	if a == nil {
+		return NewSet[T]()
+	}
+
+	return a.Filter(func(elem T) bool {
+		return b == nil || !b.Has(elem)
+	})
+}
+
+// SymmetricDifferenceOf returns a new set with deep copies of the elements
+// that are in exactly one of a and b. It is the copying form of
+// SymmetricDifference.
+//
+// Parameters:
+//   - a: The first set. If nil, it is treated as empty.
+//   - b: The second set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *Set[T]: The new set. Never returns nil.
+func SymmetricDifferenceOf[T pkg.Type](a, b *Set[T]) *Set[T] {
+	result := copy_set(a)
+	result.SymmetricDifference(copy_set(b))
+
+	return result
+}
+
+// PowerSet returns the set of all the subsets of a set, made of deep copies of
+// the elements. There is no in-place form, as the element type changes.
+//
+// Parameters:
+//   - s: The set. If nil, it is treated as empty.
+//
+// Returns:
+//   - *Set[*Set[T]]: The new set, from the empty set up to the full one. Never
+//     returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If the set has more than MaxPowerSetSize elements.
+func PowerSet[T pkg.Type](s *Set[T]) *Set[*Set[T]] {
+	var values []T
+
+	if s != nil {
+		pkg.Ensure(false, s)
+
+		values = s.values
+	}
+
+	pkg.ThrowIf(len(values) > MaxPowerSetSize, pkg.NewIllegalArgument(errors.New("set is too large for a power set")))
+
+	result := NewSet[*Set[T]]()
+
+	for mask := 0; mask < 1<<len(values); mask++ {
+		subset := NewSet[T]()
+
+		for i, elem := range values {
+			if mask&(1<<i) != 0 {
+				subset.values = append(subset.values, pkg.DeepCopy(elem))
+			}
+		}
+
+		result.values = append(result.values, subset)
+	}
+
+	return result
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// int_set creates a set of integers.
+func int_set(values ...int) *Set[*Int] {
+	s := NewSet[*Int]()
+
+	for _, v := range values {
+		s.Add(NewInt().WithValue(v))
+	}
+
+	return s
+}
+
+func TestSetEquals(t *testing.T) {
+	if !int_set(1, 2, 3).Equals(int_set(3, 1, 2)) {
+		t.Errorf("expected the order not to matter")
+	}
+
+	if int_set(1, 2).Equals(int_set(1, 3)) {
+		t.Errorf("expected sets with different elements to differ")
+	}
+}
+
+func TestSetOps(t *testing.T) {
+	a := int_set(1, 2, 3, 4)
+	b := int_set(3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  *Set[*Int]
+		want *Set[*Int]
+	}{
+		{"union", UnionOf(a, b), int_set(1, 2, 3, 4, 5)},
+		{"intersection", IntersectionOf(a, b), int_set(3, 4)},
+		{"difference", DifferenceOf(a, b), int_set(1, 2)},
+		{"symmetric difference", SymmetricDifferenceOf(a, b), int_set(1, 2, 5)},
+	}
+
+	for _, tt := range tests {
+		if !tt.got.Equals(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, tt.got)
+		}
+	}
+
+	if !a.Equals(int_set(1, 2, 3, 4)) || !b.Equals(int_set(3, 4, 5)) {
+		t.Fatalf("expected the copying forms to leave %s and %s unchanged", a, b)
+	}
+
+	if n := a.Intersection(b); n != 2 || !a.Equals(int_set(3, 4)) {
+		t.Errorf("expected to remove 2 elements, got %d: %s", n, a)
+	}
+
+	if !a.IsSubset(b) || !b.IsSuperset(a) || a.IsDisjoint(b) {
+		t.Errorf("expected %s to be a subset of %s", a, b)
+	}
+
+	if n := b.Retain(func(elem *Int) bool { return elem.Value() > 3 }); n != 1 {
+		t.Errorf("expected to remove 1 element, got %d: %s", n, b)
+	}
+}
+
+func TestSetRemovePop(t *testing.T) {
+	s := int_set(1, 2, 3)
+
+	if elem, ok := s.Remove(NewInt().WithValue(2)); !ok || elem.Value() != 2 {
+		t.Errorf("expected to remove 2, got %v, %t", elem, ok)
+	}
+
+	if _, ok := s.Remove(NewInt().WithValue(2)); ok {
+		t.Errorf("expected 2 to be gone")
+	}
+
+	if elem, ok := s.Pop(); !ok || elem.Value() != 3 {
+		t.Errorf("expected to pop 3, got %v, %t", elem, ok)
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	power := PowerSet(int_set(1, 2, 3))
+	if power.Size() != 8 || !power.Has(int_set(3, 1)) || !power.Has(NewSet[*Int]()) {
+		t.Errorf("unexpected power set: %s", power)
+	}
+
+	big := NewSet[*Int]()
+	for i := 0; i <= MaxPowerSetSize; i++ {
+		big.Add(NewInt().WithValue(i))
+	}
+
+	if code := code_of(func() { PowerSet(big) }); code != pkg.IllegalArgument {
+		t.Errorf("expected IllegalArgument, got %v", code)
+	}
+}
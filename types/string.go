@@ -0,0 +1,434 @@
+package types
+
+import (
+	"hash/maphash"
+	"iter"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PlayerR9/GoSD/event"
+	"github.com/PlayerR9/GoSD/pkg"
+	"github.com/PlayerR9/GoSD/slices"
+	"golang.org/x/text/unicode/norm"
+)
+
+// seed is the seed of the string hashes. It is random, so hashes differ
+// between runs.
+var seed = maphash.MakeSeed()
+
+// String is a string. Its positions are counted in runes, except for Len
+// which counts bytes. Methods that transform the string return a new one.
+type String struct {
+	// value is the string value.
+	value string
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
+}
+
+// String implements the fmt.Stringer interface.
+func (s *String) String() string {
+	s.poison.Check("s")
+
+	return s.value
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (s *String) DeepCopy() pkg.Type {
+	s.poison.Check("s")
+
+	return &String{
+		value: s.value,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (s *String) Ensure() {
+	pkg.ThrowIf(s == nil, pkg.NewInvalidState("s", pkg.NewNilValue()))
+
+	s.poison.Check("s")
+}
+
+// Clean implements the pkg.Type interface.
+func (s *String) Clean() {
+	if s == nil {
+		return
+	}
+
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	s.value = ""
+
+	s.poison.Mark()
+	s.observers = nil
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two strings are equal if they have the same bytes. See EqualFold for a
+// case and normalization-insensitive comparison.
+func (s *String) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, s)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *String:
+		return s.value == other.value
+	default:
+		return false
+	}
+}
+
+// NewString creates a new string.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *String: The new string. Never returns nil.
+func NewString(value string) *String {
+	return &String{
+		value: value,
+	}
+}
+
+// Value returns the string value.
+//
+// Returns:
+//   - string: The string value.
+func (s String) Value() string {
+	s.poison.Check("s")
+
+	return s.value
+}
+
+// Set sets the string value.
+//
+// Parameters:
+//   - value: The new value.
+func (s *String) Set(value string) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(s.frozen, pkg.NewFrozen("s"))
+
+	old := s.value
+	s.value = value
+
+	if s.observers != nil {
+		s.observers.Notify(event.Set[string]{Index: -1, Old: old, New: value})
+	}
+}
+
+// Len returns the length of the string in bytes.
+//
+// Returns:
+//   - int: The number of bytes.
+func (s String) Len() int {
+	s.poison.Check("s")
+
+	return len(s.value)
+}
+
+// RuneLen returns the length of the string in runes. Invalid UTF-8 bytes count
+// as one rune each.
+//
+// Returns:
+//   - int: The number of runes.
+func (s String) RuneLen() int {
+	s.poison.Check("s")
+
+	return utf8.RuneCountInString(s.value)
+}
+
+// IsEmpty checks whether the string is empty.
+//
+// Returns:
+//   - bool: True if the string is empty, false otherwise.
+func (s String) IsEmpty() bool {
+	s.poison.Check("s")
+
+	return s.value == ""
+}
+
+// Runes returns an iterator over the runes of the string. Invalid UTF-8 bytes
+// are yielded as utf8.RuneError.
+//
+// Returns:
+//   - iter.Seq[rune]: The iterator. Never returns nil.
+func (s String) Runes() iter.Seq[rune] {
+	s.poison.Check("s")
+
+	fn := func(yield func(rune) bool) {
+		for _, r := range s.value {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Bytes returns an iterator over the bytes of the string.
+//
+// Returns:
+//   - iter.Seq[byte]: The iterator. Never returns nil.
+func (s String) Bytes() iter.Seq[byte] {
+	s.poison.Check("s")
+
+	fn := func(yield func(byte) bool) {
+		for i := 0; i < len(s.value); i++ {
+			if !yield(s.value[i]) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Lines returns an iterator over the lines of the string, without their "\n"
+// or "\r\n" terminator. A final terminator does not start an empty line.
+//
+// Returns:
+//   - iter.Seq[*String]: The iterator. Never returns nil.
+func (s String) Lines() iter.Seq[*String] {
+	s.poison.Check("s")
+
+	fn := func(yield func(*String) bool) {
+		rest := s.value
+
+		for rest != "" {
+			line, after, _ := strings.Cut(rest, "\n")
+			rest = after
+
+			line = strings.TrimSuffix(line, "\r")
+
+			if !yield(NewString(line)) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Split splits the string around each instance of sep, as strings.Split.
+//
+// Parameters:
+//   - sep: The separator. If empty, the string is split into its runes.
+//
+// Returns:
+//   - *slices.Slice[*String]: The parts. Never returns nil.
+func (s String) Split(sep string) *slices.Slice[*String] {
+	s.poison.Check("s")
+
+	parts := strings.Split(s.value, sep)
+
+	values := make([]*String, 0, len(parts))
+
+	for _, part := range parts {
+		values = append(values, NewString(part))
+	}
+
+	return slices.NewSlice[*String]().WithValue(values)
+}
+
+// Join concatenates the strings of a slice, placing sep between them.
+//
+// Parameters:
+//   - elems: The strings. If nil, the result is empty.
+//   - sep: The separator.
+//
+// Returns:
+//   - *String: The new string. Never returns nil.
+func Join(elems *slices.Slice[*String], sep string) *String {
+	if elems == nil {
+		return NewString("")
+	}
+
+	var builder strings.Builder
+
+	var i int
+
+	for elem := range elems.Each() {
+		if i > 0 {
+			builder.WriteString(sep)
+		}
+
+		builder.WriteString(elem.Value())
+		i++
+	}
+
+	return NewString(builder.String())
+}
+
+// Contains checks whether the string contains substr.
+//
+// Parameters:
+//   - substr: The substring.
+//
+// Returns:
+//   - bool: True if the string contains substr, false otherwise.
+func (s String) Contains(substr string) bool {
+	s.poison.Check("s")
+
+	return strings.Contains(s.value, substr)
+}
+
+// Index returns the position, in runes, of the first instance of substr.
+//
+// Parameters:
+//   - substr: The substring.
+//
+// Returns:
+//   - int: The rune index, or -1 if substr is not in the string.
+func (s String) Index(substr string) int {
+	s.poison.Check("s")
+
+	i := strings.Index(s.value, substr)
+	if i < 0 {
+		return -1
+	}
+
+	return utf8.RuneCountInString(s.value[:i])
+}
+
+// ToLower returns the string with all letters mapped to lower case.
+//
+// Returns:
+//   - *String: The new string. Never returns nil.
+func (s String) ToLower() *String {
+	s.poison.Check("s")
+
+	return NewString(strings.ToLower(s.value))
+}
+
+// ToUpper returns the string with all letters mapped to upper case.
+//
+// Returns:
+//   - *String: The new string. Never returns nil.
+func (s String) ToUpper() *String {
+	s.poison.Check("s")
+
+	return NewString(strings.ToUpper(s.value))
+}
+
+// Fold returns the case-folded form of the string, suitable as a key for
+// case-insensitive lookups.
+//
+// Returns:
+//   - *String: The new string. Never returns nil.
+func (s String) Fold() *String {
+	s.poison.Check("s")
+
+	return NewString(strings.ToLower(strings.ToUpper(s.value)))
+}
+
+// Normalize returns the canonical decomposition (NFD) of the string, so that
+// a precomposed letter such as "\u00e9" and its decomposed form "e\u0301"
+// become the same.
+//
+// Returns:
+//   - *String: The new string. Never returns nil.
+func (s String) Normalize() *String {
+	s.poison.Check("s")
+
+	return NewString(norm.NFD.String(s.value))
+}
+
+// EqualFold checks whether the string is equal to other under simple Unicode
+// case folding and canonical equivalence; "Éte" equals "éTE".
+//
+// Parameters:
+//   - other: The other string.
+//
+// Returns:
+//   - bool: True if the strings are equal, false otherwise.
+//
+// Panics if other is nil.
+func (s *String) EqualFold(other *String) bool {
+	pkg.Ensure(false, s)
+	pkg.Ensure(false, other)
+
+	return strings.EqualFold(norm.NFD.String(s.value), norm.NFD.String(other.value))
+}
+
+// Compare implements the pkg.Comparer interface.
+//
+// The strings are compared byte-wise, as strings.Compare does.
+func (s *String) Compare(other *String) int {
+	pkg.Ensure(false, s)
+	pkg.Ensure(false, other)
+
+	return strings.Compare(s.value, other.value)
+}
+
+// Hash implements the pkg.Hasher interface.
+func (s String) Hash() uint64 {
+	s.poison.Check("s")
+
+	return maphash.String(seed, s.value)
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (s *String) Freeze() {
+	pkg.Ensure(false, s)
+
+	s.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (s String) IsFrozen() bool {
+	return s.frozen
+}
+
+// Subscribe subscribes to the changes of the string.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Set[string] changes, synchronously
+//     after the mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (s *String) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, s)
+
+	if s.observers == nil {
+		s.observers = &event.Observers{}
+	}
+
+	return s.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the string into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (s *String) Batch(fn func()) {
+	pkg.Ensure(false, s)
+
+	s.observers.Batch(fn)
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Set[string].
+func (s *String) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, s)
+
+	change, ok := c.(event.Set[string])
+	pkg.ThrowIf(!ok, event.NewNotRevertible(c, s))
+
+	s.Set(change.Old)
+
+	return event.Set[string]{Index: -1, Old: change.New, New: change.Old}
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestStringIterators(t *testing.T) {
+	s := NewString("héllo\r\nwörld\n")
+
+	if s.Len() != 15 || s.RuneLen() != 13 {
+		t.Errorf("expected 15 bytes and 13 runes, got %d and %d", s.Len(), s.RuneLen())
+	}
+
+	var runes []rune
+	for r := range s.Runes() {
+		runes = append(runes, r)
+	}
+
+	if len(runes) != 13 || runes[1] != 'é' {
+		t.Errorf("unexpected runes: %q", runes)
+	}
+
+	var lines []string
+	for line := range s.Lines() {
+		lines = append(lines, line.Value())
+	}
+
+	if len(lines) != 2 || lines[0] != "héllo" || lines[1] != "wörld" {
+		t.Errorf("unexpected lines: %q", lines)
+	}
+
+	if s.Index("wö") != 7 || s.Index("x") != -1 {
+		t.Errorf("unexpected rune index: %d", s.Index("wö"))
+	}
+}
+
+func TestStringSplitJoin(t *testing.T) {
+	parts := NewString("a,b,,c").Split(",")
+
+	if parts.Size() != 4 {
+		t.Fatalf("expected 4 parts, got %d", parts.Size())
+	}
+
+	if joined := Join(parts, "-"); joined.Value() != "a-b--c" {
+		t.Errorf("expected a-b--c, got %s", joined)
+	}
+}
+
+func TestStringEqualFold(t *testing.T) {
+	composed := NewString("\u00c9t\u00e9")
+	decomposed := NewString("e\u0301TE\u0301")
+
+	if composed.Equals(decomposed) {
+		t.Errorf("expected Equals to compare bytes")
+	}
+
+	if !composed.EqualFold(decomposed) {
+		t.Errorf("expected %q to fold to %q", composed, decomposed)
+	}
+
+	// The order of marks with different combining classes does not matter.
+	a := NewString("\u1ead")
+	b := NewString("a\u0302\u0323")
+
+	if !a.EqualFold(b) || a.Normalize().Value() != b.Normalize().Value() {
+		t.Errorf("expected %q and %q to be canonically equivalent", a, b)
+	}
+
+	// Scripts outside of the Latin, Greek and Cyrillic blocks are normalized
+	// too.
+	syllable := NewString("\uac00")
+	jamo := NewString("\u1100\u1161")
+
+	if !syllable.EqualFold(jamo) {
+		t.Errorf("expected %q and %q to be canonically equivalent", syllable, jamo)
+	}
+
+	if NewString("Straße").Fold().Value() != NewString("STRAßE").Fold().Value() {
+		t.Errorf("expected case folding to ignore case")
+	}
+}
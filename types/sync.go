@@ -0,0 +1,376 @@
+package types
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// SyncSet is a set that is safe for concurrent use.
+//
+// By default, the readers share a read lock and the writers take the write
+// lock. In copy-on-write mode, the writers work on a copy of the set that
+// is published once they are done, and the readers never lock: they see the
+// last published set. This suits read-heavy workloads, as every write
+// deep-copies the set, elements included, so that the published sets are
+// never mutated nor cleaned.
+//
+// Otherwise, the elements are shared, not copied; they must be safe for
+// concurrent use on their own if they are mutated.
+type SyncSet[T pkg.Type] struct {
+	// mu serializes the writers and, unless in copy-on-write mode, excludes
+	// the readers while writing.
+	mu sync.RWMutex
+
+	// current is the current set. In copy-on-write mode, a published set
+	// is never mutated.
+	current atomic.Pointer[Set[T]]
+
+	// cow is whether the set is in copy-on-write mode. It is fixed at
+	// creation.
+	cow bool
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+}
+
+// String implements the fmt.Stringer interface.
+func (s *SyncSet[T]) String() string {
+	s.poison.Check("s")
+
+	var str string
+
+	s.view(func(set *Set[T]) {
+		str = "Sync" + set.String()
+	})
+
+	return str
+}
+
+// DeepCopy implements the pkg.Type interface.
+//
+// The copy is in the same mode as the set.
+func (s *SyncSet[T]) DeepCopy() pkg.Type {
+	if s == nil {
+		return nil
+	}
+
+	s.poison.Check("s")
+
+	var set *Set[T]
+
+	s.view(func(current *Set[T]) {
+		set = current.DeepCopy().(*Set[T])
+	})
+
+	s_copy := &SyncSet[T]{
+		cow: s.cow,
+	}
+
+	s_copy.current.Store(set)
+
+	return s_copy
+}
+
+// Ensure implements the pkg.Type interface.
+func (s *SyncSet[T]) Ensure() {
+	pkg.ThrowIf(s == nil, pkg.NewInvalidState("s", pkg.NewNilValue()))
+
+	s.poison.Check("s")
+}
+
+// Clean implements the pkg.Type interface.
+//
+// The set must not be used concurrently with Clean.
+func (s *SyncSet[T]) Clean() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.current.Swap(NewSet[T]())
+	if old != nil {
+		pkg.CleanSlice(old.values)
+	}
+
+	s.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two sync sets are equal if their sets are equal at the time they are
+// read, regardless of their mode.
+func (s *SyncSet[T]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, s)
+	pkg.Ensure(false, other)
+
+	other_val, ok := other.(*SyncSet[T])
+	if !ok {
+		return false
+	}
+
+	// Read one set at a time so that two concurrent calls with swapped
+	// arguments cannot deadlock.
+	current := &Set[T]{
+		values: s.snapshot(),
+	}
+
+	other_current := &Set[T]{
+		values: other_val.snapshot(),
+	}
+
+	return current.Equals(other_current)
+}
+
+// NewSyncSet creates a new empty sync set that locks its readers while
+// writing.
+//
+// Returns:
+//   - *SyncSet[T]: The new set. Never returns nil.
+func NewSyncSet[T pkg.Type]() *SyncSet[T] {
+	s := &SyncSet[T]{}
+	s.current.Store(NewSet[T]())
+
+	return s
+}
+
+// NewCopyOnWriteSet creates a new empty sync set in copy-on-write mode.
+//
+// Returns:
+//   - *SyncSet[T]: The new set. Never returns nil.
+func NewCopyOnWriteSet[T pkg.Type]() *SyncSet[T] {
+	s := &SyncSet[T]{
+		cow: true,
+	}
+	s.current.Store(NewSet[T]())
+
+	return s
+}
+
+// WithValue sets the values of the set. Duplicates are dropped and the
+// previous values are cleaned, except in copy-on-write mode: the readers may
+// still hold the published set, so its values are left to the garbage
+// collector.
+//
+// Parameters:
+//   - slice: The values.
+//
+// Returns:
+//   - *SyncSet[T]: The set. If the receiver is nil, a new sync set that
+//     locks its readers is created. Never returns nil.
+func (s *SyncSet[T]) WithValue(slice []T) *SyncSet[T] {
+	if s == nil {
+		s = NewSyncSet[T]()
+	}
+
+	s.poison.Check("s")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next *Set[T]
+
+	old := s.current.Swap(next.WithValue(slice))
+
+	if !s.cow {
+		pkg.CleanSlice(old.values)
+	}
+
+	return s
+}
+
+// IsCopyOnWrite checks whether the set is in copy-on-write mode.
+//
+// Returns:
+//   - bool: True if the set is in copy-on-write mode, false otherwise.
+func (s *SyncSet[T]) IsCopyOnWrite() bool {
+	pkg.Ensure(false, s)
+
+	return s.cow
+}
+
+// view calls fn with the current set, which must not be mutated nor
+// retained.
+//
+// Parameters:
+//   - fn: The function to call.
+func (s *SyncSet[T]) view(fn func(set *Set[T])) {
+	if !s.cow {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+
+	fn(s.current.Load())
+}
+
+// snapshot returns the current values. In copy-on-write mode, they are the
+// published ones and no copy is made.
+//
+// Returns:
+//   - []T: The values. They must not be mutated.
+func (s *SyncSet[T]) snapshot() []T {
+	var values []T
+
+	s.view(func(set *Set[T]) {
+		if s.cow {
+			values = set.values
+		} else {
+			values = append([]T(nil), set.values...)
+		}
+	})
+
+	return values
+}
+
+// View calls fn with the current set, excluding the writers until it
+// returns. In copy-on-write mode, the writers are not excluded and fn sees
+// the set as it was when View was called.
+//
+// Parameters:
+//   - fn: The function to call. It must not mutate nor retain the set.
+//
+// Panics if fn is nil.
+func (s *SyncSet[T]) View(fn func(set *Set[T])) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(fn == nil, pkg.NewInvalidCall("fn", pkg.NewNilValue()))
+
+	s.view(fn)
+}
+
+// Update calls fn with the set, excluding the other writers and, unless in
+// copy-on-write mode, the readers until it returns. This makes compound
+// operations atomic.
+//
+// In copy-on-write mode, fn receives a deep copy of the set that is
+// published when fn returns; if fn panics, nothing is published. As the
+// elements are copied too, fn may mutate or clean them without affecting the
+// readers.
+//
+// Parameters:
+//   - fn: The function that mutates the set. It must not retain the set.
+//
+// Panics if fn is nil.
+func (s *SyncSet[T]) Update(fn func(set *Set[T])) {
+	pkg.Ensure(false, s)
+	pkg.ThrowIf(fn == nil, pkg.NewInvalidCall("fn", pkg.NewNilValue()))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.current.Load()
+
+	if !s.cow {
+		fn(current)
+		return
+	}
+
+	next := current.DeepCopy().(*Set[T])
+
+	fn(next)
+
+	s.current.Store(next)
+}
+
+// Add adds an element to the set. If the element is already in the set, this
+// method does nothing.
+//
+// Parameters:
+//   - elem: The element to add.
+//
+// Returns:
+//   - bool: True if the element was added, false otherwise.
+func (s *SyncSet[T]) Add(elem T) bool {
+	var ok bool
+
+	s.Update(func(set *Set[T]) {
+		ok = set.Add(elem)
+	})
+
+	return ok
+}
+
+// Has checks whether the set contains the given element.
+//
+// Parameters:
+//   - elem: The element to check.
+//
+// Returns:
+//   - bool: True if the set contains the element, false otherwise.
+func (s *SyncSet[T]) Has(elem T) bool {
+	pkg.Ensure(false, s)
+
+	var ok bool
+
+	s.view(func(set *Set[T]) {
+		ok = set.Has(elem)
+	})
+
+	return ok
+}
+
+// IsEmpty checks whether the set is empty.
+//
+// Returns:
+//   - bool: True if the set is empty, false otherwise.
+func (s *SyncSet[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Size returns the number of elements in the set.
+//
+// Returns:
+//   - int: The number of elements in the set.
+func (s *SyncSet[T]) Size() int {
+	pkg.Ensure(false, s)
+
+	var size int
+
+	s.view(func(set *Set[T]) {
+		size = len(set.values)
+	})
+
+	return size
+}
+
+// Snapshot returns a copy of the set. The elements are shared.
+//
+// Returns:
+//   - *Set[T]: The copy. Never returns nil.
+func (s *SyncSet[T]) Snapshot() *Set[T] {
+	pkg.Ensure(false, s)
+
+	values := s.snapshot()
+
+	if s.cow {
+		values = append([]T(nil), values...)
+	}
+
+	return &Set[T]{
+		values: values,
+	}
+}
+
+// Each returns an iterator over the elements of the set as they were when
+// Each was called. Concurrent writes are not seen by the iterator.
+//
+// Returns:
+//   - iter.Seq[T]: The iterator. Never returns nil.
+func (s *SyncSet[T]) Each() iter.Seq[T] {
+	pkg.Ensure(false, s)
+
+	values := s.snapshot()
+
+	fn := func(yield func(T) bool) {
+		for _, elem := range values {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+func TestSyncSetCopyOnWriteElements(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	set := NewCopyOnWriteSet[*Int]()
+	set.Add(NewInt().WithValue(1))
+
+	snapshot := set.Each()
+
+	set.Update(func(set *Set[*Int]) {
+		for elem := range set.Each() {
+			elem.Set(5)
+		}
+
+		set.WithValue([]*Int{NewInt().WithValue(2)})
+	})
+
+	for elem := range snapshot {
+		if elem.Value() != 1 {
+			t.Errorf("expected the reader to keep 1, got %s", elem)
+		}
+	}
+}
+
+func TestSyncSetCopyOnWriteWithValue(t *testing.T) {
+	prev := pkg.IsDebug()
+	pkg.SetDebug(true)
+	defer pkg.SetDebug(prev)
+
+	s := NewCopyOnWriteSet[*Int]().WithValue([]*Int{NewInt().WithValue(1)})
+
+	seq := s.Each()
+	s.WithValue(nil)
+
+	for elem := range seq {
+		if elem.Value() != 1 {
+			t.Errorf("expected the reader to keep 1, got %s", elem)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for j := 0; j < 200; j++ {
+			for elem := range s.Each() {
+				_ = elem.String()
+			}
+		}
+	}()
+
+	for j := 0; j < 200; j++ {
+		s.WithValue([]*Int{NewInt().WithValue(j)})
+	}
+
+	wg.Wait()
+}
+
+func TestSyncSet(t *testing.T) {
+	for _, s := range []*SyncSet[*Int]{NewSyncSet[*Int](), NewCopyOnWriteSet[*Int]()} {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 50; j++ {
+					s.Add(NewInt().WithValue(j))
+				}
+			}()
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 50; j++ {
+					s.Has(NewInt().WithValue(j))
+
+					for range s.Each() {
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if s.Size() != 50 {
+			t.Errorf("expected 50 elements, got %d", s.Size())
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package types
+
+import (
+	"time"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// Time is an instant in time, as a time.Time. Unlike Wrap[time.Time], it
+// compares instants with time.Time.Equal, so that the monotonic clock reading
+// and the location do not matter. Its arithmetic methods update the time in
+// place.
+type Time struct {
+	// value is the time value, without monotonic clock reading.
+	value time.Time
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// The time is written in RFC 3339 format, in UTC and with as many fractional
+// digits as needed, so that equal times have the same string.
+func (t *Time) String() string {
+	t.poison.Check("t")
+
+	return t.value.UTC().Format(time.RFC3339Nano)
+}
+
+// DeepCopy implements the pkg.Type interface.
+func (t *Time) DeepCopy() pkg.Type {
+	t.poison.Check("t")
+
+	return &Time{
+		value: t.value,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (t *Time) Ensure() {
+	pkg.ThrowIf(t == nil, pkg.NewInvalidState("t", pkg.NewNilValue()))
+
+	t.poison.Check("t")
+}
+
+// Clean implements the pkg.Type interface.
+func (t *Time) Clean() {
+	if t == nil {
+		return
+	}
+
+	pkg.ThrowIf(t.frozen, pkg.NewFrozen("t"))
+
+	t.poison.Mark()
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two times are equal if they are the same instant, as in time.Time.Equal.
+func (t *Time) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, t)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *Time:
+		return t.value.Equal(other.value)
+	default:
+		return false
+	}
+}
+
+// NewTime creates a new time. The monotonic clock reading is dropped; the
+// location is kept.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - *Time: The new time. Never returns nil.
+func NewTime(value time.Time) *Time {
+	return &Time{
+		value: value.Round(0),
+	}
+}
+
+// ParseTime parses a time in RFC 3339 format, with or without fractional
+// seconds.
+//
+// Parameters:
+//   - str: The string to parse.
+//
+// Returns:
+//   - *Time: The parsed time. Nil if an error occurred.
+//   - error: An error if the string is not a valid time.
+//
+// Errors:
+//   - *IllegalArgument: If the string is not a valid RFC 3339 time.
+func ParseTime(str string) (*Time, error) {
+	value, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return nil, pkg.NewIllegalArgument(err)
+	}
+
+	return NewTime(value), nil
+}
+
+// Value returns the time value.
+//
+// Returns:
+//   - time.Time: The time value, without monotonic clock reading.
+func (t *Time) Value() time.Time {
+	t.poison.Check("t")
+
+	return t.value
+}
+
+// Set sets the time value. The monotonic clock reading is dropped.
+//
+// Parameters:
+//   - value: The new value.
+//
+// Returns:
+//   - *Time: The time. Never returns nil.
+func (t *Time) Set(value time.Time) *Time {
+	pkg.Ensure(false, t)
+	pkg.ThrowIf(t.frozen, pkg.NewFrozen("t"))
+
+	t.value = value.Round(0)
+
+	return t
+}
+
+// Add moves the time by a duration.
+//
+// Parameters:
+//   - d: The duration.
+//
+// Returns:
+//   - *Time: The time. Never returns nil.
+//
+// Panics if d is nil.
+func (t *Time) Add(d *Duration) *Time {
+	pkg.Ensure(false, t)
+	pkg.Ensure(false, d)
+
+	return t.Set(t.value.Add(d.value))
+}
+
+// AddDays moves the time by a number of calendar days in its location, as
+// time.Time.AddDate does; the wall clock is kept across daylight saving
+// changes.
+//
+// Parameters:
+//   - n: The number of days. Negative to move backward.
+//
+// Returns:
+//   - *Time: The time. Never returns nil.
+func (t *Time) AddDays(n int) *Time {
+	pkg.Ensure(false, t)
+
+	return t.Set(t.value.AddDate(0, 0, n))
+}
+
+// Sub returns the duration from another time to this one.
+//
+// Parameters:
+//   - other: The other time.
+//
+// Returns:
+//   - *Duration: The duration, saturated at the bounds of time.Duration as
+//     in time.Time.Sub. Never returns nil.
+//
+// Panics if other is nil.
+func (t *Time) Sub(other *Time) *Duration {
+	pkg.Ensure(false, t)
+	pkg.Ensure(false, other)
+
+	return NewDuration(t.value.Sub(other.value))
+}
+
+// Truncate rounds the time down to a multiple of d since the zero time, as
+// time.Time.Truncate does.
+//
+// Parameters:
+//   - d: The multiple. If not positive, the time is left unchanged.
+//
+// Returns:
+//   - *Time: The time. Never returns nil.
+func (t *Time) Truncate(d time.Duration) *Time {
+	pkg.Ensure(false, t)
+
+	return t.Set(t.value.Truncate(d))
+}
+
+// Date returns the calendar date of the time in its location.
+//
+// Returns:
+//   - *Date: The date. Never returns nil.
+func (t *Time) Date() *Date {
+	pkg.Ensure(false, t)
+
+	return DateOf(t.value)
+}
+
+// Compare implements the pkg.Comparer interface.
+func (t *Time) Compare(other *Time) int {
+	pkg.Ensure(false, t)
+	pkg.Ensure(false, other)
+
+	return t.value.Compare(other.value)
+}
+
+// Hash implements the pkg.Hasher interface.
+//
+// Equal instants have the same hash, whatever their location.
+func (t *Time) Hash() uint64 {
+	t.poison.Check("t")
+
+	return uint64(t.value.Unix())*1_000_000_007 ^ uint64(t.value.Nanosecond())
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (t *Time) Freeze() {
+	pkg.Ensure(false, t)
+
+	t.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (t *Time) IsFrozen() bool {
+	return t.frozen
+}
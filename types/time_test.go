@@ -0,0 +1,85 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+func TestTimeEquals(t *testing.T) {
+	now := time.Now()
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		paris = time.FixedZone("CET", 3600)
+	}
+
+	a := NewTime(now)
+	b := NewTime(now.In(paris))
+
+	if !a.Equals(b) || a.Hash() != b.Hash() || a.String() != b.String() {
+		t.Errorf("expected %s and %s to be the same instant", a, b)
+	}
+}
+
+func TestTimeArithmetic(t *testing.T) {
+	start, err := ParseTime("2024-02-28T10:30:15.5Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	end := start.DeepCopy().(*Time).AddDays(2).Truncate(time.Hour)
+
+	if end.String() != "2024-03-01T10:00:00Z" {
+		t.Errorf("expected the leap day to be counted, got %s", end)
+	}
+
+	if d := end.Sub(start); d.String() != "47h29m44.5s" {
+		t.Errorf("unexpected difference: %s", d)
+	}
+
+	if _, err := ParseTime("2024-02-30T00:00:00Z"); err == nil {
+		t.Errorf("expected an error for an invalid date")
+	}
+}
+
+func TestDate(t *testing.T) {
+	d, err := ParseDate("2023-12-30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var days []string
+	for day := range d.Each(NewDate(2024, time.January, 2)) {
+		days = append(days, day.String())
+	}
+
+	if len(days) != 3 || days[2] != "2024-01-01" {
+		t.Errorf("unexpected dates: %v", days)
+	}
+
+	if n := NewDate(1960, time.March, 1).Sub(NewDate(1960, time.February, 1)); n != 29 {
+		t.Errorf("expected 29 days, got %d", n)
+	}
+
+	if !NewDate(2023, time.December, 32).Equals(NewDate(2024, time.January, 1)) {
+		t.Errorf("expected out-of-range days to be normalized")
+	}
+}
+
+func TestDuration(t *testing.T) {
+	d, err := ParseDuration("1h30m")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d.Add(NewDuration(-2 * time.Hour)).Abs()
+	if d.Value() != 30*time.Minute {
+		t.Errorf("expected 30m, got %s", d)
+	}
+
+	if code := code_of(func() { NewDuration(1 << 62).Add(NewDuration(1 << 62)) }); code != pkg.Overflow {
+		t.Errorf("expected Overflow, got %v", code)
+	}
+}
@@ -0,0 +1,233 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/PlayerR9/GoSD/event"
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+// WrapFuncs are the functions that give a WrapFunc its semantics. Every field
+// is optional.
+type WrapFuncs[T any] struct {
+	// Equal compares two values. Defaults to reflect.DeepEqual.
+	Equal func(a, b T) bool
+
+	// Copy returns an independent copy of a value. Defaults to a shallow copy.
+	Copy func(value T) T
+
+	// Clean releases the resources of a value. Defaults to doing nothing.
+	Clean func(value T)
+
+	// Format returns the string representation of a value. Defaults to
+	// fmt.Sprint.
+	Format func(value T) string
+}
+
+// WrapFunc is a wrapper for values of any type, such as slices, maps and
+// funcs, whose equality, copy, cleanup and formatting are given as functions.
+type WrapFunc[T any] struct {
+	// value is the wrapped value.
+	value T
+
+	// fns are the functions of the wrap.
+	fns WrapFuncs[T]
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
+}
+
+// String implements the fmt.Stringer interface.
+func (w *WrapFunc[T]) String() string {
+	w.poison.Check("w")
+
+	if w.fns.Format != nil {
+		return w.fns.Format(w.value)
+	}
+
+	return fmt.Sprint(w.value)
+}
+
+// DeepCopy implements the pkg.Type interface.
+//
+// The value is copied with the Copy function; the copy shares the functions.
+func (w *WrapFunc[T]) DeepCopy() pkg.Type {
+	w.poison.Check("w")
+
+	value := w.value
+
+	if w.fns.Copy != nil {
+		value = w.fns.Copy(value)
+	}
+
+	return &WrapFunc[T]{
+		value: value,
+		fns:   w.fns,
+	}
+}
+
+// Ensure implements the pkg.Type interface.
+func (w *WrapFunc[T]) Ensure() {
+	pkg.ThrowIf(w == nil, pkg.NewInvalidState("w", pkg.NewNilValue()))
+
+	w.poison.Check("w")
+}
+
+// Clean implements the pkg.Type interface.
+//
+// The value is cleaned with the Clean function.
+func (w *WrapFunc[T]) Clean() {
+	if w == nil {
+		return
+	}
+
+	pkg.ThrowIf(w.frozen, pkg.NewFrozen("w"))
+
+	if w.fns.Clean != nil {
+		w.fns.Clean(w.value)
+	}
+
+	w.value = *new(T)
+
+	w.poison.Mark()
+	w.observers = nil
+}
+
+// Equals implements the pkg.Type interface.
+//
+// Two wraps are equal if the Equal function of the receiver reports their
+// values as equal.
+func (w *WrapFunc[T]) Equals(other pkg.Type) bool {
+	pkg.Ensure(false, w)
+	pkg.Ensure(false, other)
+
+	switch other := other.(type) {
+	case *WrapFunc[T]:
+		return w.equal(w.value, other.value)
+	default:
+		return false
+	}
+}
+
+// equal compares two values with the Equal function.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//
+// Returns:
+//   - bool: True if the values are equal, false otherwise.
+func (w *WrapFunc[T]) equal(a, b T) bool {
+	if w.fns.Equal != nil {
+		return w.fns.Equal(a, b)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// NewWrapFunc creates a new wrap.
+//
+// Parameters:
+//   - value: The value to wrap.
+//   - fns: The functions of the wrap.
+//
+// Returns:
+//   - *WrapFunc[T]: The new wrap. Never returns nil.
+func NewWrapFunc[T any](value T, fns WrapFuncs[T]) *WrapFunc[T] {
+	return &WrapFunc[T]{
+		value: value,
+		fns:   fns,
+	}
+}
+
+// Value returns the wrapped value. It is not copied.
+//
+// Returns:
+//   - T: The wrapped value.
+func (w *WrapFunc[T]) Value() T {
+	w.poison.Check("w")
+
+	return w.value
+}
+
+// Set sets the wrapped value. The previous value is not cleaned.
+//
+// Parameters:
+//   - value: The new value.
+func (w *WrapFunc[T]) Set(value T) {
+	pkg.Ensure(false, w)
+	pkg.ThrowIf(w.frozen, pkg.NewFrozen("w"))
+
+	old := w.value
+	w.value = value
+
+	if w.observers != nil {
+		w.observers.Notify(event.Set[T]{Index: -1, Old: old, New: value})
+	}
+}
+
+// Freeze implements the pkg.Freezer interface.
+//
+// Only the wrap is frozen; the wrapped value can still be mutated in place.
+func (w *WrapFunc[T]) Freeze() {
+	pkg.Ensure(false, w)
+
+	w.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (w *WrapFunc[T]) IsFrozen() bool {
+	return w.frozen
+}
+
+// Subscribe subscribes to the changes of the wrap.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Set[T] changes,
+//     synchronously after the mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (w *WrapFunc[T]) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, w)
+
+	if w.observers == nil {
+		w.observers = &event.Observers{}
+	}
+
+	return w.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the wrap into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (w *WrapFunc[T]) Batch(fn func()) {
+	pkg.Ensure(false, w)
+
+	w.observers.Batch(fn)
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Set[T].
+func (w *WrapFunc[T]) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, w)
+
+	change, ok := c.(event.Set[T])
+	pkg.ThrowIf(!ok, event.NewNotRevertible(c, w))
+
+	w.Set(change.Old)
+
+	return event.Set[T]{Index: -1, Old: change.New, New: change.Old}
+}
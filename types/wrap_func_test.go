@@ -0,0 +1,103 @@
+package types
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/PlayerR9/GoSD/pkg"
+)
+
+func TestWrapFunc(t *testing.T) {
+	var cleaned int
+
+	fns := WrapFuncs[map[string]int]{
+		Copy:  maps.Clone[map[string]int],
+		Clean: func(m map[string]int) { cleaned++ },
+	}
+
+	w := NewWrapFunc(map[string]int{"a": 1}, fns)
+
+	w_copy := w.DeepCopy().(*WrapFunc[map[string]int])
+	w_copy.Value()["b"] = 2
+
+	if len(w.Value()) != 1 {
+		t.Fatalf("expected the copy to be independent, got %s", w)
+	}
+
+	w.Value()["b"] = 2
+	if !w.Equals(w_copy) {
+		t.Errorf("expected %s to equal %s", w, w_copy)
+	}
+
+	w.Clean()
+	if cleaned != 1 {
+		t.Errorf("expected the Clean function to run once, ran %d times", cleaned)
+	}
+}
+
+func TestWrapFuncFormat(t *testing.T) {
+	fns := WrapFuncs[[]int]{
+		Equal:  func(a, b []int) bool { return len(a) == len(b) },
+		Format: func(s []int) string { return "len " + NewInt().WithValue(len(s)).String() },
+	}
+
+	a := NewWrapFunc([]int{1, 2}, fns)
+	b := NewWrapFunc([]int{3, 4}, fns)
+
+	if !a.Equals(b) || a.String() != "len 2" {
+		t.Errorf("expected the custom functions to be used, got %s", a)
+	}
+
+	if NewSet[*WrapFunc[[]int]]().WithValue([]*WrapFunc[[]int]{a, b}).Size() != 1 {
+		t.Errorf("expected the set to use the custom equality")
+	}
+
+	if !slices.Equal(a.DeepCopy().(*WrapFunc[[]int]).Value(), []int{1, 2}) {
+		t.Errorf("expected the default copy to keep the value")
+	}
+}
+
+func TestWrapValidator(t *testing.T) {
+	positive := func(x int) error {
+		if x <= 0 {
+			return errors.New("value must be positive")
+		}
+
+		return nil
+	}
+
+	w := NewWrap(1).WithValidator(positive)
+	w.Set(5)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"set", func() { w.Set(-1) }},
+		{"with validator", func() { NewWrap(0).WithValidator(positive) }},
+	}
+
+	for _, tt := range tests {
+		if code := code_of(tt.fn); code != pkg.IllegalArgument {
+			t.Errorf("%s: expected IllegalArgument, got %v", tt.name, code)
+		}
+	}
+
+	if w.Value() != 5 || code_of(func() { w.DeepCopy().(*Wrap[int]).Set(0) }) != pkg.IllegalArgument {
+		t.Errorf("expected the copy to keep the validator")
+	}
+
+	z := NewWrap(0)
+
+	if code := code_of(func() { z.WithValidator(positive) }); code != pkg.IllegalArgument {
+		t.Fatalf("expected IllegalArgument, got %v", code)
+	}
+
+	z.Set(-3)
+
+	if !z.Equals(NewWrap(-3)) || code_of(func() { z.WithValidator(nil) }) != -1 {
+		t.Errorf("expected a rejected validator to leave the wrap usable")
+	}
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 
+	"github.com/PlayerR9/GoSD/event"
 	"github.com/PlayerR9/GoSD/pkg"
 )
 
@@ -10,31 +11,78 @@ import (
 type Wrap[T comparable] struct {
 	// value is the wrapped value.
 	value T
+
+	// validate checks the wrapped value, if not nil.
+	validate func(value T) error
+
+	// poison marks the value as cleaned in debug mode.
+	poison pkg.Poison
+
+	// frozen is whether the value is read-only.
+	frozen bool
+
+	// observers are the subscribers to the changes. Nil until the first
+	// subscription.
+	observers *event.Observers
 }
 
 // String implements the fmt.Stringer interface.
 func (w *Wrap[T]) String() string {
+	w.poison.Check("w")
+
 	return fmt.Sprint(w.value)
 }
 
 // DeepCopy implements the pkg.Type interface.
 func (w *Wrap[T]) DeepCopy() pkg.Type {
+	w.poison.Check("w")
+
 	return &Wrap[T]{
-		value: w.value,
+		value:    w.value,
+		validate: w.validate,
 	}
 }
 
 // Ensure implements the pkg.Type interface.
+//
+// Throws:
+//   - *IllegalArgument: If the validator rejects the value.
 func (w *Wrap[T]) Ensure() {
 	pkg.ThrowIf(w == nil, pkg.NewInvalidState("w", pkg.NewNilValue()))
+
+	w.poison.Check("w")
+
+	w.check(w.value)
+}
+
+// check panics if the validator rejects a value.
+//
+// Parameters:
+//   - value: The value.
+func (w *Wrap[T]) check(value T) {
+	if w.validate == nil {
+		return
+	}
+
+	err := w.validate(value)
+	pkg.ThrowIf(err != nil, pkg.NewIllegalArgument(err))
 }
 
 // Clean implements the pkg.Type interface.
-func (w *Wrap[T]) Clean() {}
+func (w *Wrap[T]) Clean() {
+	if w == nil {
+		return
+	}
+
+	pkg.ThrowIf(w.frozen, pkg.NewFrozen("w"))
+
+	w.poison.Mark()
+	w.observers = nil
+}
 
 // Equals implements the pkg.Type interface.
 //
-// Two wraps are equal if they have the same value adn are both wraps.
+// Two wraps are equal if they have the same value and are both wraps.
 func (w *Wrap[T]) Equals(other pkg.Type) bool {
 	pkg.Ensure(false, w)
 	pkg.Ensure(false, other)
@@ -60,11 +108,40 @@ func NewWrap[T comparable](value T) *Wrap[T] {
 	}
 }
 
+// WithValidator sets the function that checks every value of the wrap,
+// starting with the current one. A nil validator removes the check.
+//
+// Parameters:
+//   - fn: The validator. It returns an error if the value is not valid.
+//
+// Returns:
+//   - *Wrap[T]: The receiver. Never returns nil.
+//
+// Throws:
+//   - *IllegalArgument: If fn rejects the current value.
+func (w *Wrap[T]) WithValidator(fn func(value T) error) *Wrap[T] {
+	pkg.Ensure(false, w)
+	pkg.ThrowIf(w.frozen, pkg.NewFrozen("w"))
+
+	// The current value is checked before the validator is installed, so that
+	// a rejected validator leaves the wrap usable.
+	if fn != nil {
+		err := fn(w.value)
+		pkg.ThrowIf(err != nil, pkg.NewIllegalArgument(err))
+	}
+
+	w.validate = fn
+
+	return w
+}
+
 // Value returns the wrapped value.
 //
 // Returns:
 //   - T: The wrapped value.
 func (w Wrap[T]) Value() T {
+	w.poison.Check("w")
+
 	return w.value
 }
 
@@ -72,6 +149,74 @@ func (w Wrap[T]) Value() T {
 //
 // Parameters:
 //   - value: The new value.
+//
+// Throws:
+//   - *IllegalArgument: If the validator rejects the value.
 func (w *Wrap[T]) Set(value T) {
+	pkg.Ensure(false, w)
+	pkg.ThrowIf(w.frozen, pkg.NewFrozen("w"))
+	w.check(value)
+
+	old := w.value
 	w.value = value
+
+	if w.observers != nil {
+		w.observers.Notify(event.Set[T]{Index: -1, Old: old, New: value})
+	}
+}
+
+// Freeze implements the pkg.Freezer interface.
+func (w *Wrap[T]) Freeze() {
+	pkg.Ensure(false, w)
+
+	w.frozen = true
+}
+
+// IsFrozen implements the pkg.Freezer interface.
+func (w Wrap[T]) IsFrozen() bool {
+	return w.frozen
+}
+
+// Subscribe subscribes to the changes of the wrap.
+//
+// Parameters:
+//   - fn: The callback. It receives event.Set[T] changes,
+//     synchronously after the mutation.
+//
+// Returns:
+//   - func(): The function that unsubscribes the callback. Never returns nil.
+//
+// Panics if fn is nil. Clean drops every subscription.
+func (w *Wrap[T]) Subscribe(fn func(event.Change)) func() {
+	pkg.Ensure(false, w)
+
+	if w.observers == nil {
+		w.observers = &event.Observers{}
+	}
+
+	return w.observers.Subscribe(fn)
+}
+
+// Batch calls fn and merges the changes it makes to the wrap into a single
+// notification. See event.Observers.Batch.
+//
+// Parameters:
+//   - fn: The function that makes the changes.
+func (w *Wrap[T]) Batch(fn func()) {
+	pkg.Ensure(false, w)
+
+	w.observers.Batch(fn)
+}
+
+// Revert implements the event.Reverter interface. The change must be an
+// event.Set[T].
+func (w *Wrap[T]) Revert(c event.Change) event.Change {
+	pkg.Ensure(false, w)
+
+	change, ok := c.(event.Set[T])
+	pkg.ThrowIf(!ok, event.NewNotRevertible(c, w))
+
+	w.Set(change.Old)
+
+	return event.Set[T]{Index: -1, Old: change.New, New: change.Old}
 }